@@ -0,0 +1,184 @@
+package ktncordgo
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DiscordMaxChoices is the most choices discord accepts in a single autocomplete response.
+const DiscordMaxChoices = 25
+
+// CommandName returns the name of the command being typed.
+//
+// See: [discordgo.ApplicationCommandInteractionData.Name]
+func (self *DiscordAutocompleteUnit) CommandName() string {
+	return self.interaction.ApplicationCommandData().Name
+}
+
+// CommandPath returns the full path of the command being typed, including subcommand groups and subcommands.
+//
+// See: [DiscordInteractionUnit.CommandPath]
+func (self *DiscordAutocompleteUnit) CommandPath() string {
+	return commandPath(self.interaction.ApplicationCommandData())
+}
+
+// FocusedOption returns the name of the option the user is typing in.
+//
+// See: [discordgo.ApplicationCommandInteractionDataOption.Focused]
+func (self *DiscordAutocompleteUnit) FocusedOption() string {
+	focused := self.focused()
+	if focused == nil {
+		return ""
+	}
+
+	return focused.Name
+}
+
+// FocusedValue returns the partial value the user has typed so far in the focused option.
+func (self *DiscordAutocompleteUnit) FocusedValue() string {
+	focused := self.focused()
+	if focused == nil {
+		return ""
+	}
+
+	return fmt.Sprint(focused.Value)
+}
+
+// OptionValue returns the value of another option the user has already filled in, e.g. to narrow down the suggestions.
+//
+// Parameters:
+//   name - The name of the option.
+//
+// Returns the value as a string, or an empty string if the option is not filled in.
+func (self *DiscordAutocompleteUnit) OptionValue(name string) string {
+	for _, option := range commandOptions(self.interaction.ApplicationCommandData().Options) {
+		if option.Name == name && option.Value != nil {
+			return fmt.Sprint(option.Value)
+		}
+	}
+
+	return ""
+}
+
+// RespondChoices responds to the autocomplete interaction with a list of suggestions.
+// Discord accepts at most [DiscordMaxChoices] choices, so trim longer lists before responding.
+//
+// Parameters:
+//   choices - The suggestions, in the order they are shown.
+//
+// Returns an error on failure, wrapping [ErrTooManyChoices] if more choices are passed than discord accepts.
+//
+// See: [DiscordChoice]
+// See: [discordgo.InteractionApplicationCommandAutocompleteResult]
+func (self *DiscordAutocompleteUnit) RespondChoices(choices ...DiscordChoice) error {
+	if len(choices) > DiscordMaxChoices {
+		return fmt.Errorf("%w: %d of at most %d", ErrTooManyChoices, len(choices), DiscordMaxChoices)
+	}
+
+	return self.respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: convertAll(choices, func (choice DiscordChoice) *discordgo.ApplicationCommandOptionChoice {
+				return choice.Build()
+			}),
+		},
+	}, DiscordResponseReplied)
+}
+
+// focused returns the option the user is typing in, or nil.
+func (self *DiscordAutocompleteUnit) focused() *discordgo.ApplicationCommandInteractionDataOption {
+	for _, option := range commandOptions(self.interaction.ApplicationCommandData().Options) {
+		if option.Focused {
+			return option
+		}
+	}
+
+	return nil
+}
+
+// OnAutocomplete registers a handler for every autocomplete interaction.
+// When using a [CommandRouter], prefer [CommandRouter.Autocomplete] to register providers per option.
+//
+// Parameters:
+//   callback - The autocomplete handler.
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordAutocompleteUnit]
+func (self *DiscordUnit) OnAutocomplete(callback IDiscordAutocompleteFn) IDiscordSubscription {
+	return self.addHandler(self.autocompleteHandler(callback), false)
+}
+
+// autocompleteHandler wraps an autocomplete callback into an event listener.
+func (self *DiscordUnit) autocompleteHandler(callback IDiscordAutocompleteFn) *discordListener {
+	return &discordListener{
+		eventType: interactionCreateType,
+		match: func (native any) bool {
+			return native.(*discordgo.InteractionCreate).Type == discordgo.InteractionApplicationCommandAutocomplete
+		},
+		handle: func (event *DiscordEvent) error {
+			return callback(event.interaction.(*DiscordAutocompleteUnit))
+		},
+	}
+}
+
+// Autocomplete registers an autocomplete provider for an option of a command path.
+// Options with a provider are marked for autocompletion in [CommandRouter.ApplicationCommands].
+//
+// Parameters:
+//   path - The full path of the command, as used with [CommandRouter.Command].
+//   option - The name of the option.
+//   provider - The autocomplete handler, usually responding with [DiscordAutocompleteUnit.RespondChoices].
+//
+// Returns the router, allowing calls to be chained.
+//
+// See: [IDiscordAutocompleteFn]
+func (self *CommandRouter) Autocomplete(path string, option string, provider IDiscordAutocompleteFn) *CommandRouter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	path = normalizeCommandPath(path)
+	if self.autocomplete[path] == nil {
+		self.autocomplete[path] = make(map[string]IDiscordAutocompleteFn)
+	}
+
+	self.autocomplete[path][option] = provider
+	return self
+}
+
+// DispatchAutocomplete finds the provider for the focused option of the interaction's command path and runs it.
+// Interactions without a matching provider are left unanswered.
+//
+// Parameters:
+//   interaction - The autocomplete interaction to route.
+//
+// Returns the error of the provider.
+//
+// See: [CommandRouter.Autocomplete]
+func (self *CommandRouter) DispatchAutocomplete(interaction IDiscordAutocompleteUnit) error {
+	self.mutex.RLock()
+	provider := self.autocomplete[interaction.CommandPath()][interaction.FocusedOption()]
+	self.mutex.RUnlock()
+
+	if provider == nil {
+		return nil
+	}
+
+	return provider(interaction)
+}
+
+// markAutocomplete enables autocompletion on the options of a path that have a provider.
+// Fixed choices are dropped from those options, as discord does not allow both. The caller must hold the read lock.
+func (self *CommandRouter) markAutocomplete(path string, options []*discordgo.ApplicationCommandOption) {
+	providers := self.autocomplete[path]
+
+	for _, option := range options {
+		if providers[option.Name] == nil {
+			continue
+		}
+
+		option.Autocomplete = true
+		option.Choices = nil
+	}
+}
@@ -0,0 +1,346 @@
+package ktncordgo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var (
+	userUnitType = reflect.TypeOf((*IDiscordUserUnit)(nil)).Elem()
+	channelUnitType = reflect.TypeOf((*IDiscordChannelUnit)(nil)).Elem()
+	roleUnitType = reflect.TypeOf((*IDiscordRoleUnit)(nil)).Elem()
+	attachmentType = reflect.TypeOf((*discordgo.MessageAttachment)(nil))
+)
+
+// optionField describes a struct field bound to a command option through its `option` tag.
+//
+// Tag format:
+//   option:"name,required" - The option name and flags. An empty name uses the lowercase field name, "-" skips the field.
+//   default:"value" - The value used when the option is missing.
+//
+// See [BuildCommand] for the tags used when generating command definitions.
+type optionField struct {
+	index []int
+	name string
+	required bool
+	defaultValue *string
+	fieldType reflect.Type
+	tag reflect.StructTag
+}
+
+// optionLookupFn resolves the option of a field and assigns it to the target value.
+// Returns false if the option was not provided.
+type optionLookupFn func(field *optionField, target reflect.Value) (bool, error)
+
+// parseOptionFields reads the option fields of a struct type.
+func parseOptionFields(structType reflect.Type) ([]*optionField, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: got %s", ErrInvalidBindTarget, structType)
+	}
+
+	result := make([]*optionField, 0, structType.NumField())
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup("option")
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := strings.TrimSpace(parts[0])
+		if !hasTag || name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		option := &optionField{
+			index: field.Index,
+			name: name,
+			fieldType: field.Type,
+			tag: field.Tag,
+		}
+
+		for _, flag := range parts[1:] {
+			switch strings.TrimSpace(flag) {
+			case "required":
+				option.required = true
+			case "":
+			default:
+				return nil, fmt.Errorf("%w: field '%s' has unknown option flag '%s'", ErrInvalidBindTarget, field.Name, flag)
+			}
+		}
+
+		if value, ok := field.Tag.Lookup("default"); ok {
+			option.defaultValue = &value
+		}
+
+		if !isBindableType(field.Type) {
+			return nil, fmt.Errorf("%w: field '%s' has unsupported type %s", ErrInvalidBindTarget, field.Name, field.Type)
+		}
+
+		result = append(result, option)
+	}
+
+	return result, nil
+}
+
+// bindStruct fills the option fields of dst using a lookup function, applying defaults and required checks.
+func bindStruct(dst any, lookup optionLookupFn) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return ErrInvalidBindTarget
+	}
+
+	value = value.Elem()
+
+	fields, err := parseOptionFields(value.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		target := value.FieldByIndex(field.index)
+
+		found, err := lookup(field, target)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			continue
+		}
+
+		if field.defaultValue != nil {
+			err = assignString(field.name, target, *field.defaultValue)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if field.required {
+			return &DiscordOptionError{
+				Option: field.name,
+				Kind: DiscordOptionErrorMissing,
+			}
+		}
+
+		target.SetZero()
+	}
+
+	return nil
+}
+
+// assignString parses a text value into a basic target value.
+func assignString(name string, target reflect.Value, raw string) error {
+	if target.Kind() == reflect.Pointer && isBasicKind(target.Type().Elem().Kind()) {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	var err error
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Bool:
+		var parsed bool
+		parsed, err = strconv.ParseBool(raw)
+		target.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var parsed int64
+		parsed, err = strconv.ParseInt(raw, 10, target.Type().Bits())
+		target.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var parsed uint64
+		parsed, err = strconv.ParseUint(raw, 10, target.Type().Bits())
+		target.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		var parsed float64
+		parsed, err = strconv.ParseFloat(raw, target.Type().Bits())
+		target.SetFloat(parsed)
+	default:
+		err = fmt.Errorf("cannot parse text into %s", target.Type())
+	}
+
+	if err != nil {
+		return &DiscordOptionError{
+			Option: name,
+			Kind: DiscordOptionErrorValue,
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// assignOption converts a command option value into the target value, resolving users, channels, roles and attachments.
+func assignOption(discord *DiscordUnit, guildId string, name string, target reflect.Value, option *discordgo.ApplicationCommandInteractionDataOption, resolved *discordgo.ApplicationCommandInteractionDataResolved) error {
+	typeError := &DiscordOptionError{
+		Option: name,
+		Kind: DiscordOptionErrorType,
+		Err: fmt.Errorf("cannot bind %s option into %s", option.Type, target.Type()),
+	}
+
+	unresolved := &DiscordOptionError{
+		Option: name,
+		Kind: DiscordOptionErrorUnresolved,
+		Err: fmt.Errorf("%s '%v' not found in resolved data", option.Type, option.Value),
+	}
+
+	if resolved == nil {
+		resolved = &discordgo.ApplicationCommandInteractionDataResolved{}
+	}
+
+	switch target.Type() {
+	case userUnitType:
+		if option.Type != discordgo.ApplicationCommandOptionUser && option.Type != discordgo.ApplicationCommandOptionMentionable {
+			return typeError
+		}
+
+		user, ok := resolved.Users[fmt.Sprint(option.Value)]
+		if !ok {
+			return unresolved
+		}
+
+		target.Set(reflect.ValueOf(&DiscordUserUnit{
+			discord: discord,
+			user: user,
+		}))
+		return nil
+	case channelUnitType:
+		if option.Type != discordgo.ApplicationCommandOptionChannel {
+			return typeError
+		}
+
+		channel, ok := resolved.Channels[fmt.Sprint(option.Value)]
+		if !ok {
+			return unresolved
+		}
+
+		target.Set(reflect.ValueOf(&DiscordChannelUnit{
+			discord: discord,
+			channel: channel,
+		}))
+		return nil
+	case roleUnitType:
+		if option.Type != discordgo.ApplicationCommandOptionRole && option.Type != discordgo.ApplicationCommandOptionMentionable {
+			return typeError
+		}
+
+		role, ok := resolved.Roles[fmt.Sprint(option.Value)]
+		if !ok {
+			return unresolved
+		}
+
+		target.Set(reflect.ValueOf(&DiscordRoleUnit{
+			discord: discord,
+			guildId: guildId,
+			role: role,
+		}))
+		return nil
+	case attachmentType:
+		if option.Type != discordgo.ApplicationCommandOptionAttachment {
+			return typeError
+		}
+
+		attachment, ok := resolved.Attachments[fmt.Sprint(option.Value)]
+		if !ok {
+			return unresolved
+		}
+
+		target.Set(reflect.ValueOf(attachment))
+		return nil
+	}
+
+	if target.Kind() == reflect.Pointer {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		value, ok := option.Value.(string)
+		if !ok {
+			return typeError
+		}
+
+		target.SetString(value)
+	case reflect.Bool:
+		value, ok := option.Value.(bool)
+		if !ok {
+			return typeError
+		}
+
+		target.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, ok := option.Value.(float64)
+		if !ok || option.Type != discordgo.ApplicationCommandOptionInteger {
+			return typeError
+		}
+
+		if target.OverflowInt(int64(value)) {
+			return &DiscordOptionError{Option: name, Kind: DiscordOptionErrorValue, Err: fmt.Errorf("%v overflows %s", value, target.Type())}
+		}
+
+		target.SetInt(int64(value))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, ok := option.Value.(float64)
+		if !ok || option.Type != discordgo.ApplicationCommandOptionInteger {
+			return typeError
+		}
+
+		if value < 0 || target.OverflowUint(uint64(value)) {
+			return &DiscordOptionError{Option: name, Kind: DiscordOptionErrorValue, Err: fmt.Errorf("%v overflows %s", value, target.Type())}
+		}
+
+		target.SetUint(uint64(value))
+	case reflect.Float32, reflect.Float64:
+		value, ok := option.Value.(float64)
+		if !ok {
+			return typeError
+		}
+
+		target.SetFloat(value)
+	default:
+		return typeError
+	}
+
+	return nil
+}
+
+// isBindableType returns true if a struct field of the given type can be bound to an option.
+func isBindableType(fieldType reflect.Type) bool {
+	switch fieldType {
+	case userUnitType, channelUnitType, roleUnitType, attachmentType:
+		return true
+	}
+
+	if fieldType.Kind() == reflect.Pointer {
+		return isBasicKind(fieldType.Elem().Kind())
+	}
+
+	return isBasicKind(fieldType.Kind())
+}
+
+// isBasicKind returns true for the kinds that map onto string, boolean, integer and number options.
+func isBasicKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
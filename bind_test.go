@@ -0,0 +1,203 @@
+package ktncordgo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// commandInteraction creates a slash command interaction unit with the given options and resolved data.
+func commandInteraction(options []*discordgo.ApplicationCommandInteractionDataOption, resolved *discordgo.ApplicationCommandInteractionDataResolved) *DiscordInteractionUnit {
+	return &DiscordInteractionUnit{
+		DiscordBaseInteractionUnit: DiscordBaseInteractionUnit{
+			discord: &DiscordUnit{},
+			interaction: &discordgo.InteractionCreate{
+				Interaction: &discordgo.Interaction{
+					Type: discordgo.InteractionApplicationCommand,
+					GuildID: "guild",
+					Data: discordgo.ApplicationCommandInteractionData{
+						Name: "test",
+						Options: options,
+						Resolved: resolved,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBindFillsFields(t *testing.T) {
+	type args struct {
+		Name string `option:"name,required"`
+		Count int `option:"count"`
+		Ratio *float64 `option:"ratio"`
+		Missing *int `option:"missing"`
+		Flag bool `option:"flag" default:"true"`
+		User IDiscordUserUnit `option:"user"`
+		Role IDiscordRoleUnit `option:"role"`
+		Skipped string `option:"-"`
+	}
+
+	interaction := commandInteraction([]*discordgo.ApplicationCommandInteractionDataOption{
+		{
+			Name: "sub",
+			Type: discordgo.ApplicationCommandOptionSubCommand,
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "name", Type: discordgo.ApplicationCommandOptionString, Value: "alpha"},
+				{Name: "count", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(3)},
+				{Name: "ratio", Type: discordgo.ApplicationCommandOptionNumber, Value: 0.5},
+				{Name: "user", Type: discordgo.ApplicationCommandOptionUser, Value: "u1"},
+				{Name: "role", Type: discordgo.ApplicationCommandOptionRole, Value: "r1"},
+			},
+		},
+	}, &discordgo.ApplicationCommandInteractionDataResolved{
+		Users: map[string]*discordgo.User{"u1": {ID: "u1"}},
+		Roles: map[string]*discordgo.Role{"r1": {ID: "r1", Name: "mods"}},
+	})
+
+	result := args{Skipped: "kept", Missing: new(int)}
+	err := interaction.Bind(&result)
+	if err != nil {
+		t.Fatalf("Bind returned %v", err)
+	}
+
+	if result.Name != "alpha" || result.Count != 3 || result.Ratio == nil || *result.Ratio != 0.5 {
+		t.Errorf("basic values not bound: %+v", result)
+	}
+
+	if result.Missing != nil {
+		t.Errorf("missing pointer option should be reset to nil")
+	}
+
+	if !result.Flag {
+		t.Errorf("default value not applied")
+	}
+
+	if result.User == nil || result.User.Native().ID != "u1" {
+		t.Errorf("user not resolved: %v", result.User)
+	}
+
+	if result.Role == nil || result.Role.Native().ID != "r1" || result.Role.(*DiscordRoleUnit).guildId != "guild" {
+		t.Errorf("role not resolved into a role unit of the guild: %v", result.Role)
+	}
+
+	if result.Skipped != "kept" {
+		t.Errorf("skipped field was changed")
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	type required struct {
+		Name string `option:"name,required"`
+	}
+
+	type small struct {
+		Value int8 `option:"value"`
+	}
+
+	type user struct {
+		User IDiscordUserUnit `option:"value"`
+	}
+
+	tests := []struct {
+		name string
+		dst any
+		options []*discordgo.ApplicationCommandInteractionDataOption
+		kind DiscordOptionErrorKind
+	}{
+		{
+			name: "missing required",
+			dst: &required{},
+			kind: DiscordOptionErrorMissing,
+		},
+		{
+			name: "mismatched type",
+			dst: &required{},
+			options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "name", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(1)},
+			},
+			kind: DiscordOptionErrorType,
+		},
+		{
+			name: "overflow",
+			dst: &small{},
+			options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "value", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(300)},
+			},
+			kind: DiscordOptionErrorValue,
+		},
+		{
+			name: "unresolved user",
+			dst: &user{},
+			options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "value", Type: discordgo.ApplicationCommandOptionUser, Value: "u1"},
+			},
+			kind: DiscordOptionErrorUnresolved,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func (t *testing.T) {
+			err := commandInteraction(test.options, nil).Bind(test.dst)
+
+			var optionErr *DiscordOptionError
+			if !errors.As(err, &optionErr) {
+				t.Fatalf("expected a DiscordOptionError, got %v", err)
+			}
+
+			if optionErr.Kind != test.kind {
+				t.Errorf("expected kind %s, got %s", test.kind, optionErr.Kind)
+			}
+		})
+	}
+}
+
+func TestBindInvalidTarget(t *testing.T) {
+	type unknownFlag struct {
+		Name string `option:"name,sometimes"`
+	}
+
+	type unsupported struct {
+		Values []string `option:"values"`
+	}
+
+	targets := map[string]any{
+		"non-pointer": unknownFlag{},
+		"nil pointer": (*unknownFlag)(nil),
+		"unknown flag": &unknownFlag{},
+		"unsupported type": &unsupported{},
+	}
+
+	for name, dst := range targets {
+		t.Run(name, func (t *testing.T) {
+			err := commandInteraction(nil, nil).Bind(dst)
+			if !errors.Is(err, ErrInvalidBindTarget) {
+				t.Errorf("expected ErrInvalidBindTarget, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAssignStringDefaults(t *testing.T) {
+	type defaults struct {
+		Count uint `option:"count" default:"7"`
+		Ratio *float32 `option:"ratio" default:"1.5"`
+		Bad int `option:"bad" default:"seven"`
+	}
+
+	var result defaults
+	err := bindStruct(&result, func (field *optionField, target reflect.Value) (bool, error) {
+		return false, nil
+	})
+
+	var optionErr *DiscordOptionError
+	if !errors.As(err, &optionErr) || optionErr.Option != "bad" || optionErr.Kind != DiscordOptionErrorValue {
+		t.Fatalf("expected a value error for 'bad', got %v", err)
+	}
+
+	if result.Count != 7 || result.Ratio == nil || *result.Ratio != 1.5 {
+		t.Errorf("defaults not applied: %+v", result)
+	}
+}
@@ -1,8 +1,10 @@
 package ktncordgo
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -119,10 +121,10 @@ func (self *DiscordChannelUnit) FetchMessage(messageId string) (IDiscordMessageU
 // See: [discordgo.Session.ChannelMessages]
 func (self *DiscordChannelUnit) FetchMessages(limit int) ([]IDiscordMessageUnit, error) {
 	if limit > 100 {
-		log.Printf("FetchMessages limit '%d' is larger than max allowed '%d'\n", limit, 100)
+		self.discord.logf("FetchMessages limit '%d' is larger than max allowed '%d'\n", limit, 100)
 		limit = 100
 	} else if limit < 1 { // I know minimul is mentioned to be 1, but we're not just gonna error if the user pick anything less.
-		log.Printf("FetchMesssages limit '%d' is less than min allowed '%d'\n", limit, 0)
+		self.discord.logf("FetchMesssages limit '%d' is less than min allowed '%d'\n", limit, 0)
 		limit = 0
 	}
 
@@ -148,22 +150,98 @@ func (self *DiscordChannelUnit) FetchMessages(limit int) ([]IDiscordMessageUnit,
 }
 
 // GetLastMassage finds and returns the latest message in the channel.
+// The message is fetched by the last message ID of the channel, or of the channel in the state if the unit has none,
+// falling back to fetching the newest message if neither is known or the message was deleted.
 //
 // Returns the message if found, otherwise an error.
 //
 // See: [DiscordMessageUnit]
-// See: [DiscordChannelUnit.FetchMessages]
+// See: [discordgo.Channel.LastMessageID]
+// See: [discordgo.Session.ChannelMessages]
 func (self *DiscordChannelUnit) GetLastMessage() (IDiscordMessageUnit, error) {
-	messages, err := self.FetchMessages(100)
+	lastMessageId := self.channel.LastMessageID
+	if lastMessageId == "" {
+		if channel, err := self.discord.session.State.Channel(self.channel.ID); err == nil {
+			lastMessageId = channel.LastMessageID
+		}
+	}
+
+	if lastMessageId != "" {
+		msg, err := self.discord.session.ChannelMessage(self.channel.ID, lastMessageId)
+		if err == nil {
+			return self.wrapMessage(msg), nil
+		}
+
+		var restErr *discordgo.RESTError
+		if !errors.As(err, &restErr) || restErr.Message == nil || restErr.Message.Code != discordgo.ErrCodeUnknownMessage {
+			return nil, fmt.Errorf("failed to fetch last message: %w", err)
+		}
+	}
+
+	messages, err := self.discord.session.ChannelMessages(self.channel.ID, 1, "", "", "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch last message: %v", err)
+		return nil, fmt.Errorf("failed to fetch last message: %w", err)
 	}
 
 	if len(messages) == 0 {
 		return nil, fmt.Errorf("failed to fetch last message: no messages found")
 	}
 
-	return messages[0], nil
+	return self.wrapMessage(messages[0]), nil
+}
+
+// GetMessagesSince finds and returns every message sent in the channel after a point in time, oldest first.
+//
+// Parameters:
+//   ctx - The context cancelling the fetch, as a busy channel may take many pages.
+//   since - The point in time to start after.
+//
+// Returns a slice of the found messages on success, otherwise an error.
+//
+// See: [DiscordChannelUnit.History]
+func (self *DiscordChannelUnit) GetMessagesSince(ctx context.Context, since time.Time) ([]IDiscordMessageUnit, error) {
+	result := make([]IDiscordMessageUnit, 0)
+
+	for message, err := range self.History(ctx, DiscordHistoryOptions{
+		Direction: DiscordHistoryForward,
+		StartTime: since,
+	}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch messages since %v: %w", since, err)
+		}
+
+		result = append(result, message)
+	}
+
+	return result, nil
+}
+
+// GetMessagesAround finds and returns the messages surrounding a message, including the message itself, newest first.
+//
+// Parameters:
+//   messageId - The ID of the message in the middle.
+//   limit - The amount of messages to find. Min: 1. Max: 100.
+//
+// Returns a slice of the found messages on success, otherwise an error.
+//
+// See: [DiscordMessageUnit]
+// See: [discordgo.Session.ChannelMessages]
+func (self *DiscordChannelUnit) GetMessagesAround(messageId string, limit int) ([]IDiscordMessageUnit, error) {
+	if limit > 100 {
+		self.discord.logf("GetMessagesAround limit '%d' is larger than max allowed '%d'\n", limit, 100)
+		limit = 100
+	}
+
+	if limit < 1 {
+		return []IDiscordMessageUnit{}, nil
+	}
+
+	messages, err := self.discord.session.ChannelMessages(self.channel.ID, limit, "", "", messageId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch messages around '%s': %w", messageId, err)
+	}
+
+	return convertAll(messages, self.wrapMessage), nil
 }
 
 // SendMessage sends a message in the channel.
@@ -197,7 +275,10 @@ func (self *DiscordChannelUnit) SendMessage(message string) (IDiscordMessageUnit
 // See: [DiscordMessageUnit]
 // See: [discordgo.Session.ChannelMessageSendComplex]
 func (self *DiscordChannelUnit) SendMessageOptions(options DiscordMessageSend) (IDiscordMessageUnit, error) {
-	msg, err := self.discord.session.ChannelMessageSendComplex(self.channel.ID, options.Build())
+	message := options.Build()
+	message.Flags &^= discordgo.MessageFlagsEphemeral
+
+	msg, err := self.discord.session.ChannelMessageSendComplex(self.channel.ID, message)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message with options: %v", err)
 	}
@@ -0,0 +1,262 @@
+package ktncordgo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/ktnuity/ktnuitygo"
+)
+
+var channelTypeNames = map[string]discordgo.ChannelType{
+	"text": discordgo.ChannelTypeGuildText,
+	"voice": discordgo.ChannelTypeGuildVoice,
+	"category": discordgo.ChannelTypeGuildCategory,
+	"news": discordgo.ChannelTypeGuildNews,
+	"news_thread": discordgo.ChannelTypeGuildNewsThread,
+	"public_thread": discordgo.ChannelTypeGuildPublicThread,
+	"private_thread": discordgo.ChannelTypeGuildPrivateThread,
+	"stage": discordgo.ChannelTypeGuildStageVoice,
+	"forum": discordgo.ChannelTypeGuildForum,
+	"media": discordgo.ChannelTypeGuildMedia,
+}
+
+// BuildCommand generates a slash command definition from a tagged struct, the same struct used with [DiscordInteractionUnit.Bind].
+//
+// On top of the `option` and `default` tags, the following tags are read:
+//   description:"text" - The option description. Defaults to the option name.
+//   choices:"Name=value,Other=other" - Fixed choices. A choice without "=" uses the value as its name.
+//   min:"n" / max:"n" - The value range of integer and number options, or the length range of string options.
+//                       Discord omits a zero max, so max:"0" is rejected, as is a min above the max or a negative length.
+//   channel_types:"text,voice" - The allowed channel types of channel options.
+//
+// Parameters:
+//   name - The name of the command.
+//   description - The description of the command.
+//   args - The tagged struct, or a pointer to it. May be nil for commands without options.
+//
+// Returns the generated [discordgo.ApplicationCommand] on success, otherwise an error.
+//
+// See: [CommandRouter.Command]
+// See: [DiscordInteractionUnit.Bind]
+func BuildCommand(name string, description string, args any) (*discordgo.ApplicationCommand, error) {
+	options, err := buildCommandOptions(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build command '%s': %w", name, err)
+	}
+
+	return &discordgo.ApplicationCommand{
+		Type: discordgo.ChatApplicationCommand,
+		Name: name,
+		Description: describe(description, name),
+		Options: options,
+	}, nil
+}
+
+// BindCommand creates a command handler that binds the command options into a new [T] before running the callback.
+//
+// Parameters:
+//   callback - The command handler receiving the bound options.
+//
+// Returns the wrapped [IDiscordCommandFn].
+//
+// See: [DiscordInteractionUnit.Bind]
+func BindCommand[T any](callback func(IDiscordInteractionUnit, *T) error) IDiscordCommandFn {
+	return func (interaction IDiscordInteractionUnit) error {
+		args := new(T)
+
+		err := interaction.Bind(args)
+		if err != nil {
+			return err
+		}
+
+		return callback(interaction, args)
+	}
+}
+
+// buildCommandOptions generates the option definitions of a tagged struct, with required options first.
+func buildCommandOptions(args any) ([]*discordgo.ApplicationCommandOption, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsType := reflect.TypeOf(args)
+	if argsType.Kind() == reflect.Pointer {
+		argsType = argsType.Elem()
+	}
+
+	fields, err := parseOptionFields(argsType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*discordgo.ApplicationCommandOption, 0, len(fields))
+
+	for _, field := range fields {
+		option, err := buildCommandOption(field)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, option)
+	}
+
+	sort.SliceStable(result, func (a, b int) bool {
+		return result[a].Required && !result[b].Required
+	})
+
+	return result, nil
+}
+
+// buildCommandOption generates the option definition of a single field.
+func buildCommandOption(field *optionField) (*discordgo.ApplicationCommandOption, error) {
+	option := &discordgo.ApplicationCommandOption{
+		Type: optionTypeOf(field.fieldType),
+		Name: field.name,
+		Description: describe(field.tag.Get("description"), field.name),
+		Required: field.required && field.defaultValue == nil,
+	}
+
+	if raw, ok := field.tag.Lookup("choices"); ok {
+		for _, entry := range strings.Split(raw, ",") {
+			name, value, found := strings.Cut(entry, "=")
+			if !found {
+				value = name
+			}
+
+			parsed, err := parseOptionValue(option.Type, strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("option '%s' has invalid choice '%s': %w", field.name, entry, err)
+			}
+
+			option.Choices = append(option.Choices, &discordgo.ApplicationCommandOptionChoice{
+				Name: strings.TrimSpace(name),
+				Value: parsed,
+			})
+		}
+	}
+
+	minValue, hasMin, err := parseBound(field, "min")
+	if err != nil {
+		return nil, err
+	}
+
+	maxValue, hasMax, err := parseBound(field, "max")
+	if err != nil {
+		return nil, err
+	}
+
+	if hasMax && maxValue == 0 {
+		return nil, fmt.Errorf("option '%s' has max '0', which discord cannot receive as it omits a zero max", field.name)
+	}
+
+	if hasMin && hasMax && minValue > maxValue {
+		return nil, fmt.Errorf("option '%s' has min '%v' above max '%v'", field.name, minValue, maxValue)
+	}
+
+	if option.Type == discordgo.ApplicationCommandOptionString {
+		if minValue < 0 || maxValue < 0 {
+			return nil, fmt.Errorf("option '%s' has a negative length range", field.name)
+		}
+
+		if hasMin {
+			option.MinLength = ktnuitygo.AsRef(int(minValue))
+		}
+
+		if hasMax {
+			option.MaxLength = int(maxValue)
+		}
+	} else {
+		if hasMin {
+			option.MinValue = &minValue
+		}
+
+		if hasMax {
+			option.MaxValue = maxValue
+		}
+	}
+
+	if raw, ok := field.tag.Lookup("channel_types"); ok {
+		for _, name := range strings.Split(raw, ",") {
+			channelType, ok := channelTypeNames[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("option '%s' has unknown channel type '%s'", field.name, name)
+			}
+
+			option.ChannelTypes = append(option.ChannelTypes, channelType)
+		}
+	}
+
+	return option, nil
+}
+
+// parseBound parses the min or max tag of a field, returning false if the tag is not set.
+func parseBound(field *optionField, name string) (float64, bool, error) {
+	raw, ok := field.tag.Lookup(name)
+	if !ok {
+		return 0, false, nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("option '%s' has invalid %s '%s': %w", field.name, name, raw, err)
+	}
+
+	return value, true, nil
+}
+
+// optionTypeOf returns the option type matching a bindable field type.
+func optionTypeOf(fieldType reflect.Type) discordgo.ApplicationCommandOptionType {
+	switch fieldType {
+	case userUnitType:
+		return discordgo.ApplicationCommandOptionUser
+	case channelUnitType:
+		return discordgo.ApplicationCommandOptionChannel
+	case roleUnitType:
+		return discordgo.ApplicationCommandOptionRole
+	case attachmentType:
+		return discordgo.ApplicationCommandOptionAttachment
+	}
+
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return discordgo.ApplicationCommandOptionBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return discordgo.ApplicationCommandOptionInteger
+	case reflect.Float32, reflect.Float64:
+		return discordgo.ApplicationCommandOptionNumber
+	}
+
+	return discordgo.ApplicationCommandOptionString
+}
+
+// parseOptionValue parses a choice value for an option type.
+func parseOptionValue(optionType discordgo.ApplicationCommandOptionType, raw string) (any, error) {
+	switch optionType {
+	case discordgo.ApplicationCommandOptionInteger:
+		return strconv.ParseInt(raw, 10, 64)
+	case discordgo.ApplicationCommandOptionNumber:
+		return strconv.ParseFloat(raw, 64)
+	case discordgo.ApplicationCommandOptionString:
+		return raw, nil
+	}
+
+	return nil, fmt.Errorf("choices are not supported for %s options", optionType)
+}
+
+// describe returns the description, or the fallback if the description is empty.
+func describe(description string, fallback string) string {
+	if description == "" {
+		return fallback
+	}
+
+	return description
+}
@@ -0,0 +1,140 @@
+package ktncordgo
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestBuildCommandOptions(t *testing.T) {
+	type args struct {
+		Note string `option:"note" description:"A note" min:"2" max:"100"`
+		Target IDiscordUserUnit `option:"target,required"`
+		Mode string `option:"mode,required" choices:"Fast=fast,slow"`
+		Level int `option:"level" choices:"Low=1,High=10" min:"-5" max:"10"`
+		Ratio float64 `option:"ratio" default:"0.5"`
+		Where IDiscordChannelUnit `option:"where" channel_types:"text, voice"`
+		Role IDiscordRoleUnit `option:"role"`
+	}
+
+	command, err := BuildCommand("test", "", &args{})
+	if err != nil {
+		t.Fatalf("BuildCommand returned %v", err)
+	}
+
+	if command.Description != "test" || command.Type != discordgo.ChatApplicationCommand {
+		t.Errorf("unexpected command: %+v", command)
+	}
+
+	names := convertAll(command.Options, func (option *discordgo.ApplicationCommandOption) string {
+		return option.Name
+	})
+
+	expected := []string{"target", "mode", "note", "level", "ratio", "where", "role"}
+	if len(names) != len(expected) {
+		t.Fatalf("expected options %v, got %v", expected, names)
+	}
+
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("expected required options first in field order %v, got %v", expected, names)
+		}
+	}
+
+	options := make(map[string]*discordgo.ApplicationCommandOption)
+	for _, option := range command.Options {
+		options[option.Name] = option
+	}
+
+	note := options["note"]
+	if note.Type != discordgo.ApplicationCommandOptionString || note.Description != "A note" || note.MinLength == nil || *note.MinLength != 2 || note.MaxLength != 100 {
+		t.Errorf("unexpected note option: %+v", note)
+	}
+
+	mode := options["mode"]
+	if len(mode.Choices) != 2 || mode.Choices[0].Name != "Fast" || mode.Choices[0].Value != "fast" || mode.Choices[1].Name != "slow" {
+		t.Errorf("unexpected mode choices: %+v", mode.Choices)
+	}
+
+	level := options["level"]
+	if level.Type != discordgo.ApplicationCommandOptionInteger || level.MinValue == nil || *level.MinValue != -5 || level.MaxValue != 10 {
+		t.Errorf("unexpected level range: %+v", level)
+	}
+
+	if len(level.Choices) != 2 || level.Choices[1].Value != int64(10) {
+		t.Errorf("unexpected level choices: %+v", level.Choices)
+	}
+
+	if options["ratio"].Required || options["ratio"].Type != discordgo.ApplicationCommandOptionNumber {
+		t.Errorf("unexpected ratio option: %+v", options["ratio"])
+	}
+
+	where := options["where"]
+	if len(where.ChannelTypes) != 2 || where.ChannelTypes[1] != discordgo.ChannelTypeGuildVoice {
+		t.Errorf("unexpected channel types: %+v", where.ChannelTypes)
+	}
+
+	if options["role"].Type != discordgo.ApplicationCommandOptionRole || options["target"].Type != discordgo.ApplicationCommandOptionUser {
+		t.Errorf("unexpected resolved option types")
+	}
+}
+
+func TestBuildCommandOptionErrors(t *testing.T) {
+	type zeroMax struct {
+		Value int `option:"value" max:"0"`
+	}
+
+	type inverted struct {
+		Value float64 `option:"value" min:"5" max:"1"`
+	}
+
+	type negativeLength struct {
+		Value string `option:"value" min:"-1"`
+	}
+
+	type badChoice struct {
+		Value int `option:"value" choices:"One=one"`
+	}
+
+	type boolChoice struct {
+		Value bool `option:"value" choices:"yes"`
+	}
+
+	type badChannel struct {
+		Value IDiscordChannelUnit `option:"value" channel_types:"garden"`
+	}
+
+	type badMin struct {
+		Value int `option:"value" min:"low"`
+	}
+
+	tests := map[string]any{
+		"zero max": zeroMax{},
+		"min above max": inverted{},
+		"negative length": negativeLength{},
+		"invalid choice": badChoice{},
+		"choice on boolean": boolChoice{},
+		"unknown channel type": badChannel{},
+		"invalid min": badMin{},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func (t *testing.T) {
+			_, err := BuildCommand("test", "test", args)
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestBuildCommandWithoutOptions(t *testing.T) {
+	command, err := BuildCommand("ping", "Replies with pong", nil)
+	if err != nil {
+		t.Fatalf("BuildCommand returned %v", err)
+	}
+
+	if len(command.Options) != 0 || command.Description != "Replies with pong" {
+		t.Errorf("unexpected command: %+v", command)
+	}
+}
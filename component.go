@@ -0,0 +1,129 @@
+package ktncordgo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CustomId returns the custom ID of the clicked button or used select menu.
+//
+// See: [discordgo.MessageComponentInteractionData.CustomID]
+func (self *DiscordComponentUnit) CustomId() string {
+	return self.interaction.MessageComponentData().CustomID
+}
+
+// ComponentType returns the type of the component, e.g. a button or a select menu.
+//
+// See: [discordgo.MessageComponentInteractionData.ComponentType]
+func (self *DiscordComponentUnit) ComponentType() discordgo.ComponentType {
+	return self.interaction.MessageComponentData().ComponentType
+}
+
+// Values returns the selected values of a select menu.
+// For user, role and channel selects these are the IDs of the selected entities.
+//
+// See: [discordgo.MessageComponentInteractionData.Values]
+func (self *DiscordComponentUnit) Values() []string {
+	return self.interaction.MessageComponentData().Values
+}
+
+// Message returns the message the component is attached to.
+//
+// See: [DiscordMessageUnit]
+// See: [discordgo.Interaction.Message]
+func (self *DiscordComponentUnit) Message() IDiscordMessageUnit {
+	if self.interaction.Message == nil {
+		return nil
+	}
+
+	return &DiscordMessageUnit{
+		discord: self.discord,
+		message: self.interaction.Message,
+	}
+}
+
+// DeferUpdate acknowledges the interaction without a reply, allowing the message to be edited later with [EditReplyOptions].
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.InteractionRespond]
+// See: [discordgo.InteractionResponseDeferredMessageUpdate]
+func (self *DiscordComponentUnit) DeferUpdate() error {
+	return self.respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}, DiscordResponseDeferred)
+}
+
+// Update responds to the interaction by editing the message the component is attached to.
+//
+// Parameters:
+//   opts - The new message content, embeds, allowed mentions, and components.
+//
+// Returns an error on failure.
+//
+// See: [DiscordMessageSend]
+// See: [discordgo.Session.InteractionRespond]
+// See: [discordgo.InteractionResponseUpdateMessage]
+func (self *DiscordComponentUnit) Update(opts DiscordMessageSend) error {
+	return self.replyMessage(opts, discordgo.InteractionResponseUpdateMessage)
+}
+
+// OnComponent registers a handler for message components with an exact custom ID.
+//
+// Parameters:
+//   customId - The custom ID of the button or select menu.
+//   callback - The component handler.
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordComponentUnit]
+func (self *DiscordUnit) OnComponent(customId string, callback IDiscordComponentFn) IDiscordSubscription {
+	return self.addHandler(self.componentHandler(func (id string) bool {
+		return id == customId
+	}, callback), false)
+}
+
+// OnComponentPrefix registers a handler for message components whose custom ID starts with a prefix,
+// useful for IDs carrying data, like "delete:1234".
+//
+// Parameters:
+//   prefix - The custom ID prefix.
+//   callback - The component handler.
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordComponentUnit]
+func (self *DiscordUnit) OnComponentPrefix(prefix string, callback IDiscordComponentFn) IDiscordSubscription {
+	return self.addHandler(self.componentHandler(func (id string) bool {
+		return strings.HasPrefix(id, prefix)
+	}, callback), false)
+}
+
+// OnComponentMatch registers a handler for message components whose custom ID matches a pattern.
+//
+// Parameters:
+//   pattern - The pattern the custom ID must match.
+//   callback - The component handler.
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordComponentUnit]
+func (self *DiscordUnit) OnComponentMatch(pattern *regexp.Regexp, callback IDiscordComponentFn) IDiscordSubscription {
+	return self.addHandler(self.componentHandler(pattern.MatchString, callback), false)
+}
+
+// componentHandler wraps a component callback into an event listener.
+func (self *DiscordUnit) componentHandler(match func(string) bool, callback IDiscordComponentFn) *discordListener {
+	return &discordListener{
+		eventType: interactionCreateType,
+		match: func (native any) bool {
+			interaction := native.(*discordgo.InteractionCreate)
+			return interaction.Type == discordgo.InteractionMessageComponent && match(interaction.MessageComponentData().CustomID)
+		},
+		handle: func (event *DiscordEvent) error {
+			return callback(event.interaction.(*DiscordComponentUnit))
+		},
+	}
+}
@@ -0,0 +1,262 @@
+package ktncordgo
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CommandName returns the name of the context menu command.
+//
+// See: [discordgo.ApplicationCommandInteractionData.Name]
+func (self *DiscordContextMenuUnit) CommandName() string {
+	return self.interaction.ApplicationCommandData().Name
+}
+
+// CommandType returns whether the command was used on a user or a message.
+//
+// See: [discordgo.UserApplicationCommand]
+// See: [discordgo.MessageApplicationCommand]
+func (self *DiscordContextMenuUnit) CommandType() discordgo.ApplicationCommandType {
+	return self.interaction.ApplicationCommandData().CommandType
+}
+
+// TargetId returns the ID of the user or message the command was used on.
+//
+// See: [discordgo.ApplicationCommandInteractionData.TargetID]
+func (self *DiscordContextMenuUnit) TargetId() string {
+	return self.interaction.ApplicationCommandData().TargetID
+}
+
+// TargetUser returns the user a user command was used on, resolved from the interaction data.
+//
+// Returns the user, or nil for message commands.
+//
+// See: [DiscordUserUnit]
+func (self *DiscordContextMenuUnit) TargetUser() IDiscordUserUnit {
+	data := self.interaction.ApplicationCommandData()
+	if data.CommandType != discordgo.UserApplicationCommand || data.Resolved == nil {
+		return nil
+	}
+
+	user, ok := data.Resolved.Users[data.TargetID]
+	if !ok {
+		return nil
+	}
+
+	return &DiscordUserUnit{
+		discord: self.discord,
+		user: user,
+	}
+}
+
+// TargetMessage returns the message a message command was used on, resolved from the interaction data.
+//
+// Returns the message, or nil for user commands.
+//
+// See: [DiscordMessageUnit]
+func (self *DiscordContextMenuUnit) TargetMessage() IDiscordMessageUnit {
+	data := self.interaction.ApplicationCommandData()
+	if data.CommandType != discordgo.MessageApplicationCommand || data.Resolved == nil {
+		return nil
+	}
+
+	message, ok := data.Resolved.Messages[data.TargetID]
+	if !ok {
+		return nil
+	}
+
+	if message.GuildID == "" {
+		message.GuildID = self.interaction.GuildID
+	}
+
+	return &DiscordMessageUnit{
+		discord: self.discord,
+		message: message,
+	}
+}
+
+// OnUserCommand registers a user context menu command, shown when right-clicking a user.
+// The command is registered with discord by [DiscordUnit.Start] through the attached [CommandRouter].
+//
+// Parameters:
+//   name - The name of the command, as shown in the menu.
+//   callback - The command handler.
+//
+// Returns the subscription handle, used to remove the command.
+//
+// See: [CommandRouter.UserCommand]
+func (self *DiscordUnit) OnUserCommand(name string, callback IDiscordContextMenuFn) IDiscordSubscription {
+	return self.contextCommand(discordgo.UserApplicationCommand, name, callback)
+}
+
+// OnMessageCommand registers a message context menu command, shown when right-clicking a message.
+// The command is registered with discord by [DiscordUnit.Start] through the attached [CommandRouter].
+//
+// Parameters:
+//   name - The name of the command, as shown in the menu.
+//   callback - The command handler.
+//
+// Returns the subscription handle, used to remove the command.
+//
+// See: [CommandRouter.MessageCommand]
+func (self *DiscordUnit) OnMessageCommand(name string, callback IDiscordContextMenuFn) IDiscordSubscription {
+	return self.contextCommand(discordgo.MessageApplicationCommand, name, callback)
+}
+
+// contextCommand registers a context menu command on the router, returning a handle removing it again.
+// A router is created if none is attached, but only its context menu route is installed,
+// so slash commands keep going to the handlers of [DiscordUnit.OnSlashCommand] alone.
+// A router attached later with [DiscordUnit.SetRouter] takes the command over.
+func (self *DiscordUnit) contextCommand(commandType discordgo.ApplicationCommandType, name string, callback IDiscordContextMenuFn) IDiscordSubscription {
+	self.routerMutex.Lock()
+	router := self.router.Load()
+	if router == nil {
+		router = NewCommandRouter()
+		self.router.Store(router)
+	}
+
+	self.installContextRouteLocked()
+	self.routerMutex.Unlock()
+
+	router.contextCommand(commandType, name, callback)
+
+	return &DiscordSubscription{
+		remove: func () {
+			router := self.router.Load()
+			if router != nil {
+				router.RemoveContextCommand(commandType, name)
+			}
+		},
+	}
+}
+
+// contextMenuHandler wraps a context menu callback into an event listener.
+func (self *DiscordUnit) contextMenuHandler(callback IDiscordContextMenuFn) *discordListener {
+	return &discordListener{
+		eventType: interactionCreateType,
+		match: func (native any) bool {
+			interaction := native.(*discordgo.InteractionCreate)
+			return interaction.Type == discordgo.InteractionApplicationCommand && !isChatCommand(interaction)
+		},
+		handle: func (event *DiscordEvent) error {
+			return callback(event.interaction.(*DiscordContextMenuUnit))
+		},
+	}
+}
+
+// UserCommand registers a handler for a user context menu command, included in [CommandRouter.ApplicationCommands].
+//
+// Parameters:
+//   name - The name of the command, as shown in the menu.
+//   callback - The command handler.
+//
+// Returns the router, allowing calls to be chained.
+//
+// See: [IDiscordContextMenuFn]
+func (self *CommandRouter) UserCommand(name string, callback IDiscordContextMenuFn) *CommandRouter {
+	self.contextCommand(discordgo.UserApplicationCommand, name, callback)
+	return self
+}
+
+// MessageCommand registers a handler for a message context menu command, included in [CommandRouter.ApplicationCommands].
+//
+// Parameters:
+//   name - The name of the command, as shown in the menu.
+//   callback - The command handler.
+//
+// Returns the router, allowing calls to be chained.
+//
+// See: [IDiscordContextMenuFn]
+func (self *CommandRouter) MessageCommand(name string, callback IDiscordContextMenuFn) *CommandRouter {
+	self.contextCommand(discordgo.MessageApplicationCommand, name, callback)
+	return self
+}
+
+// RemoveContextCommand unregisters the handler of a context menu command.
+//
+// Parameters:
+//   commandType - Either [discordgo.UserApplicationCommand] or [discordgo.MessageApplicationCommand].
+//   name - The name of the command.
+//
+// Returns true if a handler was registered for the command.
+func (self *CommandRouter) RemoveContextCommand(commandType discordgo.ApplicationCommandType, name string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if _, ok := self.contextRoutes[commandType][name]; !ok {
+		return false
+	}
+
+	delete(self.contextRoutes[commandType], name)
+	return true
+}
+
+// DispatchContextMenu finds the handler for a context menu command and runs it.
+//
+// Parameters:
+//   interaction - The interaction to route.
+//
+// Returns the error of the handler, or an error wrapping [ErrNoHandler] if nothing matched.
+//
+// See: [CommandRouter.UserCommand]
+// See: [CommandRouter.MessageCommand]
+func (self *CommandRouter) DispatchContextMenu(interaction IDiscordContextMenuUnit) error {
+	self.mutex.RLock()
+	callback := self.contextRoutes[interaction.CommandType()][interaction.CommandName()]
+	self.mutex.RUnlock()
+
+	if callback == nil {
+		return fmt.Errorf("%w: '%s'", ErrNoHandler, interaction.CommandName())
+	}
+
+	return callback(interaction)
+}
+
+// adoptContextRoutes copies the context menu commands of another router that are not registered on this router.
+func (self *CommandRouter) adoptContextRoutes(other *CommandRouter) {
+	other.mutex.RLock()
+	routes := make(map[discordgo.ApplicationCommandType]map[string]IDiscordContextMenuFn, len(other.contextRoutes))
+	for commandType, named := range other.contextRoutes {
+		routes[commandType] = maps.Clone(named)
+	}
+	other.mutex.RUnlock()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for commandType, named := range routes {
+		if self.contextRoutes[commandType] == nil {
+			self.contextRoutes[commandType] = make(map[string]IDiscordContextMenuFn)
+		}
+
+		for name, callback := range named {
+			if _, ok := self.contextRoutes[commandType][name]; !ok {
+				self.contextRoutes[commandType][name] = callback
+			}
+		}
+	}
+}
+
+// contextCommand stores the handler of a context menu command.
+func (self *CommandRouter) contextCommand(commandType discordgo.ApplicationCommandType, name string, callback IDiscordContextMenuFn) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.contextRoutes[commandType] == nil {
+		self.contextRoutes[commandType] = make(map[string]IDiscordContextMenuFn)
+	}
+
+	self.contextRoutes[commandType][name] = callback
+}
+
+// isChatCommand returns true for slash command interactions, as opposed to context menu commands.
+func isChatCommand(interaction *discordgo.InteractionCreate) bool {
+	if interaction.Type != discordgo.InteractionApplicationCommand {
+		return false
+	}
+
+	commandType := interaction.ApplicationCommandData().CommandType
+	return commandType == 0 || commandType == discordgo.ChatApplicationCommand
+}
@@ -0,0 +1,52 @@
+package ktncordgo
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// contextCommandNames lists the context menu commands a router registers.
+func contextCommandNames(t *testing.T, router *CommandRouter) []string {
+	commands, err := router.ApplicationCommands()
+	if err != nil {
+		t.Fatalf("ApplicationCommands returned %v", err)
+	}
+
+	var result []string
+	for _, command := range commands {
+		if command.Type != discordgo.ChatApplicationCommand {
+			result = append(result, command.Name)
+		}
+	}
+
+	return result
+}
+
+func TestSetRouterKeepsContextCommands(t *testing.T) {
+	session, err := discordgo.New("Bot token")
+	if err != nil {
+		t.Fatalf("discordgo.New returned %v", err)
+	}
+
+	unit := &DiscordUnit{session: session}
+	callback := func (IDiscordContextMenuUnit) error { return nil }
+
+	info := unit.OnUserCommand("Info", callback)
+	unit.OnMessageCommand("Quote", callback)
+
+	router := NewCommandRouter().MessageCommand("Quote", callback).UserCommand("Ban", callback)
+	unit.SetRouter(router)
+
+	names := contextCommandNames(t, router)
+	if len(names) != 3 || names[0] != "Ban" || names[1] != "Info" || names[2] != "Quote" {
+		t.Fatalf("expected the earlier context commands to be carried over, got %v", names)
+	}
+
+	info.Remove()
+
+	names = contextCommandNames(t, unit.Router())
+	if len(names) != 2 || names[0] != "Ban" || names[1] != "Quote" {
+		t.Errorf("expected the subscription to remove the command from the new router, got %v", names)
+	}
+}
@@ -2,14 +2,20 @@ package ktncordgo
 
 import (
 	"io"
+	"log"
+	"net/http"
 	"time"
+
+	"github.com/bwmarrin/discordgo"
 )
 
 // DiscordMessageSend contains options used for [DiscordChannelUnit.SendMessageOptions].
+// [discordgo.MessageFlagsEphemeral] in Flags only applies to interaction responses, and is ignored for channel messages.
 //
 // See: [DiscordEmbed]
 // See: [DiscordAttachment]
 // See: [DiscordAllowedMentions]
+// See: [DiscordActionRow]
 // See: [DiscordMessageUnit]
 type DiscordMessageSend struct {
 	Content string
@@ -18,16 +24,20 @@ type DiscordMessageSend struct {
 	Attachments []*DiscordAttachment
 	AllowedMentions *DiscordAllowedMentions
 	Reference IDiscordMessageUnit
+	Components []*DiscordActionRow
+	Flags discordgo.MessageFlags
 }
 
 // DiscordMessageEdit contains options used for [DiscordMessageUnit.EditOptions].
 //
 // See: [DiscordEmbed]
 // See: [DiscordAllowedMentions]
+// See: [DiscordActionRow]
 type DiscordMessageEdit struct {
 	Content *string
 	Embeds *[]*DiscordEmbed
 	AllowedMentions *DiscordAllowedMentions
+	Components *[]*DiscordActionRow
 }
 
 // DiscordEmbed contains options used for [DiscordMessageSend] and [DiscordMessageEdit].
@@ -81,6 +91,144 @@ type DiscordAttachment struct {
 	Source io.Reader
 }
 
+// DiscordComponent is implemented by the components that can be placed in a [DiscordActionRow].
+//
+// See: [DiscordButton]
+// See: [DiscordSelectMenu]
+type DiscordComponent interface {
+	Build() discordgo.MessageComponent
+}
+
+// DiscordActionRow contains options used for [DiscordMessageSend] and [DiscordMessageEdit].
+// A row holds up to 5 buttons, or a single select menu.
+//
+// See: parent [DiscordMessageSend] or [DiscordMessageEdit]
+// See: [DiscordComponent]
+type DiscordActionRow struct {
+	Components []DiscordComponent
+}
+
+// DiscordButton contains options used for [DiscordActionRow].
+// Link buttons use URL, all other styles use CustomId.
+//
+// See: parent [DiscordActionRow]
+// See: [discordgo.ButtonStyle]
+type DiscordButton struct {
+	CustomId string
+	Label string
+	Style discordgo.ButtonStyle
+	Emoji string
+	URL string
+	Disabled bool
+}
+
+// DiscordSelectMenu contains options used for [DiscordActionRow].
+// Options only apply to string selects, ChannelTypes only to channel selects.
+//
+// See: parent [DiscordActionRow]
+// See: [DiscordSelectOption]
+// See: [discordgo.SelectMenuType]
+type DiscordSelectMenu struct {
+	Type discordgo.SelectMenuType
+	CustomId string
+	Placeholder string
+	MinValues *int
+	MaxValues int
+	Options []*DiscordSelectOption
+	ChannelTypes []discordgo.ChannelType
+	Disabled bool
+}
+
+// DiscordSelectOption contains options used for [DiscordSelectMenu].
+//
+// See: parent [DiscordSelectMenu]
+type DiscordSelectOption struct {
+	Label string
+	Value string
+	Description string
+	Emoji string
+	Default bool
+}
+
+// DiscordChoice is a single suggestion used for [DiscordAutocompleteUnit.RespondChoices].
+// The value must match the option type: a string, an integer or a number.
+//
+// See: [discordgo.ApplicationCommandOptionChoice]
+type DiscordChoice struct {
+	Name string
+	Value any
+}
+
+// DiscordModal contains options used for [DiscordBaseInteractionUnit.ShowModal].
+// Every text input is placed in its own row.
+//
+// See: [DiscordTextInput]
+type DiscordModal struct {
+	CustomId string
+	Title string
+	Inputs []*DiscordTextInput
+}
+
+// DiscordTextInput contains options used for [DiscordModal].
+// The style defaults to [discordgo.TextInputShort].
+//
+// See: parent [DiscordModal]
+// See: [discordgo.TextInputStyle]
+type DiscordTextInput struct {
+	CustomId string
+	Label string
+	Style discordgo.TextInputStyle
+	Placeholder string
+	Value string
+	Required bool
+	MinLength int
+	MaxLength int
+}
+
+// DiscordBan is a ban entry of a guild.
+//
+// See: [DiscordGuildUnit.GetBans]
+// See: [discordgo.GuildBan]
+type DiscordBan struct {
+	User IDiscordUserUnit
+	Reason string
+}
+
+// DiscordHistoryDirection is the order [DiscordChannelUnit.History] walks through messages in.
+type DiscordHistoryDirection int
+
+const (
+	DiscordHistoryBackward	DiscordHistoryDirection = iota
+	DiscordHistoryForward
+)
+
+// DiscordHistoryOptions contains options used for [DiscordChannelUnit.History].
+//
+// Backward iteration starts at the newest message, forward iteration at the oldest.
+// StartId or StartTime move the start; the message with StartId itself is not included.
+// Limit caps the number of messages, 0 means no limit. Stop ends the iteration before yielding the first message it returns true for.
+//
+// See: [DiscordHistoryDirection]
+type DiscordHistoryOptions struct {
+	Direction DiscordHistoryDirection
+	StartId string
+	StartTime time.Time
+	Limit int
+	Stop func(IDiscordMessageUnit) bool
+}
+
+// DiscordRoleParams contains options used for [DiscordGuildUnit.CreateRole] and [DiscordGuildUnit.EditRole].
+// Nil fields are left unchanged when editing, or use discord's defaults when creating.
+//
+// See: [discordgo.RoleParams]
+type DiscordRoleParams struct {
+	Name string
+	Color *int
+	Hoist *bool
+	Permissions *int64
+	Mentionable *bool
+}
+
 type DiscordMentionType string
 
 const (
@@ -98,3 +246,118 @@ type DiscordAllowedMentions struct {
 	Replieduser bool
 }
 
+
+// DiscordEventType identifies the kind of event passed through the middleware chain.
+//
+// See: [IDiscordEvent]
+type DiscordEventType string
+
+const (
+	DiscordEventSlashCommand	DiscordEventType = "slash_command"
+	DiscordEventComponent		DiscordEventType = "component"
+	DiscordEventModalSubmit		DiscordEventType = "modal_submit"
+	DiscordEventAutocomplete	DiscordEventType = "autocomplete"
+	DiscordEventContextMenu		DiscordEventType = "context_menu"
+	DiscordEventMessageCreate	DiscordEventType = "message_create"
+	DiscordEventRaw			DiscordEventType = "event"
+)
+
+// DiscordResponseState describes how far an interaction has been responded to.
+//
+// See: [DiscordBaseInteractionUnit.ResponseState]
+type DiscordResponseState int
+
+const (
+	DiscordResponseNone		DiscordResponseState = iota
+	DiscordResponseDeferred
+	DiscordResponseReplied
+)
+
+// DiscordCommandSyncOptions contains options used for [DiscordUnit.SyncCommands].
+// With CreateOnly set, added and changed commands are created one by one, and registered commands that are not passed in are kept.
+type DiscordCommandSyncOptions struct {
+	DryRun bool
+	CreateOnly bool
+}
+
+// DiscordCommandSyncReport contains the result of [DiscordUnit.SyncCommands].
+// Commands in Added and Changed are the local definitions, commands in Removed and Unchanged are the registered ones.
+//
+// See: [discordgo.ApplicationCommand]
+type DiscordCommandSyncReport struct {
+	GuildId string
+	Added []*discordgo.ApplicationCommand
+	Changed []*discordgo.ApplicationCommand
+	Removed []*discordgo.ApplicationCommand
+	Unchanged []*discordgo.ApplicationCommand
+	Applied bool
+}
+
+// DiscordCommandSyncMode controls how [DiscordUnit.Start] registers commands.
+// The zero value only creates commands, and never removes registered ones.
+// Apply has to be set explicitly, as it removes every registered command that is not passed in.
+type DiscordCommandSyncMode int
+
+const (
+	DiscordCommandSyncCreate	DiscordCommandSyncMode = iota
+	DiscordCommandSyncApply
+	DiscordCommandSyncDryRun
+	DiscordCommandSyncDisabled
+)
+
+// DiscordStateOptions contains the state tracking settings used for [DiscordUnitOptions].
+// When set, every field replaces the discordgo default, which is to track everything.
+//
+// See: [discordgo.State]
+type DiscordStateOptions struct {
+	Disabled bool
+	MaxMessageCount int
+	TrackChannels bool
+	TrackThreads bool
+	TrackEmojis bool
+	TrackStickers bool
+	TrackMembers bool
+	TrackThreadMembers bool
+	TrackRoles bool
+	TrackVoice bool
+	TrackPresences bool
+}
+
+// DiscordUnitOptions contains options used for [CreateDiscordUnitWithOptions].
+// Zero values keep the defaults of [CreateDiscordUnit].
+//
+// With InferIntents set, Intents is only the base set, extended with the intents of the registered handlers.
+// A zero Intents falls back to [DefaultDiscordIntents] either way.
+// Privileged intents are only inferred if they are part of PrivilegedIntents.
+// StrictIntents makes [DiscordUnit.Start] fail instead of warn when a handler needs a missing intent.
+// ErrorSink and ErrorReply are described at [DiscordUnit.SetErrorSink] and [DiscordUnit.SetErrorReply],
+// AutoDefer and AutoDeferFlags at [DiscordUnit.SetAutoDefer].
+// CleanupGuildCommands makes [DiscordUnit.Stop] delete the guild commands this unit synced, see [DiscordUnit.SetGuildCommandCleanup].
+//
+// See: [DiscordStateOptions]
+// See: [DiscordCommandSyncMode]
+// See: [discordgo.Intent]
+// See: [discordgo.GatewayStatusUpdate]
+type DiscordUnitOptions struct {
+	Intents discordgo.Intent
+	InferIntents bool
+	StrictIntents bool
+	PrivilegedIntents discordgo.Intent
+	Presence *discordgo.GatewayStatusUpdate
+	ShardId int
+	ShardCount int
+
+	Logger *log.Logger
+	ErrorSink func(*HandlerError)
+	ErrorReply string
+	AutoDefer time.Duration
+	AutoDeferFlags discordgo.MessageFlags
+	HTTPClient *http.Client
+	BaseURL string
+	State *DiscordStateOptions
+
+	CommandSync DiscordCommandSyncMode
+	DevGuildId string
+	GuildCommands map[string][]*discordgo.ApplicationCommand
+	CleanupGuildCommands bool
+}
@@ -1,7 +1,9 @@
 package ktncordgo
 
 import (
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -36,6 +38,8 @@ func (self *DiscordMessageSend) Build() *discordgo.MessageSend {
 		}),
 		AllowedMentions: self.AllowedMentions.Build(),
 		Reference: reference,
+		Components: buildActionRows(self.Components),
+		Flags: self.Flags,
 	}
 }
 
@@ -49,6 +53,7 @@ func (self *DiscordMessageSend) ToEdit() *DiscordMessageEdit {
 		Content: &self.Content,
 		Embeds: &self.Embeds,
 		AllowedMentions: self.AllowedMentions,
+		Components: &self.Components,
 	}
 }
 
@@ -68,13 +73,72 @@ func (self *DiscordMessageEdit) Build() *discordgo.MessageEdit {
 
 	var mentions *discordgo.MessageAllowedMentions = nil
 	if self.AllowedMentions != nil {
-		self.AllowedMentions.Build()
+		mentions = self.AllowedMentions.Build()
+	}
+
+	var components *[]discordgo.MessageComponent = nil
+	if self.Components != nil {
+		newComponents := buildActionRows(*self.Components)
+		components = &newComponents
 	}
 
 	return &discordgo.MessageEdit{
 		Content: self.Content,
 		Embeds: embeds,
 		AllowedMentions: mentions,
+		Components: components,
+	}
+}
+
+// buildWebhookParams turns [DiscordMessageSend] into [discordgo.WebhookParams], used for interaction follow-ups.
+func (self *DiscordMessageSend) buildWebhookParams() *discordgo.WebhookParams {
+	message := self.Build()
+
+	return &discordgo.WebhookParams{
+		Content: message.Content,
+		TTS: message.TTS,
+		Files: message.Files,
+		Components: message.Components,
+		Embeds: message.Embeds,
+		AllowedMentions: message.AllowedMentions,
+		Flags: message.Flags,
+	}
+}
+
+// buildWebhookEdit turns [DiscordMessageSend] into [discordgo.WebhookEdit] including its files, used to send a deferred reply.
+// Only the content, embeds and components that are set are sent, so the edit leaves the others of the message alone.
+func (self *DiscordMessageSend) buildWebhookEdit() *discordgo.WebhookEdit {
+	message := self.Build()
+
+	edit := &discordgo.WebhookEdit{
+		Files: message.Files,
+		AllowedMentions: message.AllowedMentions,
+	}
+
+	if message.Content != "" {
+		edit.Content = &message.Content
+	}
+
+	if len(message.Embeds) > 0 {
+		edit.Embeds = &message.Embeds
+	}
+
+	if len(message.Components) > 0 {
+		edit.Components = &message.Components
+	}
+
+	return edit
+}
+
+// buildWebhookEdit turns [DiscordMessageEdit] into [discordgo.WebhookEdit], used for interaction responses and follow-ups.
+func (self *DiscordMessageEdit) buildWebhookEdit() *discordgo.WebhookEdit {
+	message := self.Build()
+
+	return &discordgo.WebhookEdit{
+		Content: message.Content,
+		Components: message.Components,
+		Embeds: message.Embeds,
+		AllowedMentions: message.AllowedMentions,
 	}
 }
 
@@ -95,6 +159,11 @@ func (self *DiscordMessageEdit) ToSend() *DiscordMessageSend {
 		}
 	}
 
+	var components []*DiscordActionRow = nil
+	if self.Components != nil {
+		components = *self.Components
+	}
+
 	return &DiscordMessageSend{
 		Content: content,
 		Embeds: embeds,
@@ -102,6 +171,7 @@ func (self *DiscordMessageEdit) ToSend() *DiscordMessageSend {
 		Attachments: make([]*DiscordAttachment, 0),
 		AllowedMentions: self.AllowedMentions,
 		Reference: nil,
+		Components: components,
 	}
 }
 
@@ -241,6 +311,189 @@ func (self *DiscordAttachment) Build() *discordgo.File {
 	}
 }
 
+// Build turns [DiscordActionRow] into [discordgo.ActionsRow].
+//
+// See: [discordgo.ActionsRow]
+func (self *DiscordActionRow) Build() discordgo.MessageComponent {
+	if self == nil { return nil }
+	return &discordgo.ActionsRow{
+		Components: convertAll(self.Components, func (component DiscordComponent) discordgo.MessageComponent {
+			return component.Build()
+		}),
+	}
+}
+
+// buildActionRows turns a slice of [DiscordActionRow] into a slice of [discordgo.MessageComponent].
+func buildActionRows(rows []*DiscordActionRow) []discordgo.MessageComponent {
+	return convertAll(rows, func (row *DiscordActionRow) discordgo.MessageComponent {
+		return row.Build()
+	})
+}
+
+// Build turns [DiscordButton] into [discordgo.Button].
+// The style defaults to [discordgo.PrimaryButton], or [discordgo.LinkButton] if a URL is set.
+//
+// See: [discordgo.Button]
+func (self *DiscordButton) Build() discordgo.MessageComponent {
+	if self == nil { return nil }
+
+	style := self.Style
+	if style == 0 {
+		style = discordgo.PrimaryButton
+		if self.URL != "" {
+			style = discordgo.LinkButton
+		}
+	}
+
+	return &discordgo.Button{
+		CustomID: self.CustomId,
+		Label: self.Label,
+		Style: style,
+		Emoji: buildComponentEmoji(self.Emoji),
+		URL: self.URL,
+		Disabled: self.Disabled,
+	}
+}
+
+// Build turns [DiscordSelectMenu] into [discordgo.SelectMenu].
+// The type defaults to [discordgo.StringSelectMenu].
+//
+// See: [discordgo.SelectMenu]
+func (self *DiscordSelectMenu) Build() discordgo.MessageComponent {
+	if self == nil { return nil }
+
+	menuType := self.Type
+	if menuType == 0 {
+		menuType = discordgo.StringSelectMenu
+	}
+
+	return &discordgo.SelectMenu{
+		MenuType: menuType,
+		CustomID: self.CustomId,
+		Placeholder: self.Placeholder,
+		MinValues: self.MinValues,
+		MaxValues: self.MaxValues,
+		Options: convertAll(self.Options, func (option *DiscordSelectOption) discordgo.SelectMenuOption {
+			return option.Build()
+		}),
+		ChannelTypes: self.ChannelTypes,
+		Disabled: self.Disabled,
+	}
+}
+
+// Build turns [DiscordSelectOption] into [discordgo.SelectMenuOption].
+//
+// See: [discordgo.SelectMenuOption]
+func (self *DiscordSelectOption) Build() discordgo.SelectMenuOption {
+	if self == nil { return discordgo.SelectMenuOption{} }
+	return discordgo.SelectMenuOption{
+		Label: self.Label,
+		Value: self.Value,
+		Description: self.Description,
+		Emoji: buildComponentEmoji(self.Emoji),
+		Default: self.Default,
+	}
+}
+
+// buildComponentEmoji turns a unicode emoji into [discordgo.ComponentEmoji], or nil if empty.
+func buildComponentEmoji(emoji string) *discordgo.ComponentEmoji {
+	if emoji == "" { return nil }
+	return &discordgo.ComponentEmoji{
+		Name: emoji,
+	}
+}
+
+// Build turns [DiscordChoice] into [discordgo.ApplicationCommandOptionChoice].
+//
+// See: [discordgo.ApplicationCommandOptionChoice]
+func (self *DiscordChoice) Build() *discordgo.ApplicationCommandOptionChoice {
+	if self == nil { return nil }
+	return &discordgo.ApplicationCommandOptionChoice{
+		Name: self.Name,
+		Value: self.Value,
+	}
+}
+
+// Build turns [DiscordRoleParams] into [discordgo.RoleParams].
+//
+// See: [discordgo.RoleParams]
+func (self *DiscordRoleParams) Build() *discordgo.RoleParams {
+	if self == nil { return nil }
+	return &discordgo.RoleParams{
+		Name: self.Name,
+		Color: self.Color,
+		Hoist: self.Hoist,
+		Permissions: self.Permissions,
+		Mentionable: self.Mentionable,
+	}
+}
+
+// NewDiscordModal creates a [DiscordModal] without inputs.
+//
+// Parameters:
+//   customId - The custom ID the submission is routed by.
+//   title - The title shown at the top of the modal.
+//
+// Returns the created [DiscordModal] reference.
+//
+// See: [DiscordModal.AddInput]
+func NewDiscordModal(customId string, title string) *DiscordModal {
+	return &DiscordModal{
+		CustomId: customId,
+		Title: title,
+	}
+}
+
+// AddInput appends a text input to the modal.
+//
+// Parameters:
+//   input - The text input to append.
+//
+// Returns the modal, allowing calls to be chained.
+func (self *DiscordModal) AddInput(input *DiscordTextInput) *DiscordModal {
+	self.Inputs = append(self.Inputs, input)
+	return self
+}
+
+// Build turns [DiscordModal] into [discordgo.InteractionResponseData].
+//
+// See: [discordgo.InteractionResponseData]
+func (self *DiscordModal) Build() *discordgo.InteractionResponseData {
+	if self == nil { return nil }
+	return &discordgo.InteractionResponseData{
+		CustomID: self.CustomId,
+		Title: self.Title,
+		Components: convertAll(self.Inputs, func (input *DiscordTextInput) discordgo.MessageComponent {
+			return &discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{input.Build()},
+			}
+		}),
+	}
+}
+
+// Build turns [DiscordTextInput] into [discordgo.TextInput].
+//
+// See: [discordgo.TextInput]
+func (self *DiscordTextInput) Build() discordgo.MessageComponent {
+	if self == nil { return nil }
+
+	style := self.Style
+	if style == 0 {
+		style = discordgo.TextInputShort
+	}
+
+	return &discordgo.TextInput{
+		CustomID: self.CustomId,
+		Label: self.Label,
+		Style: style,
+		Placeholder: self.Placeholder,
+		Value: self.Value,
+		Required: self.Required,
+		MinLength: self.MinLength,
+		MaxLength: self.MaxLength,
+	}
+}
+
 // Build turns [DiscordAllowedMentions] into [discordgo.MessageAllowedMentions].
 //
 // See: [discordgo.MessageAllowedMentions]
@@ -269,6 +522,31 @@ func (self *DiscordAllowedMentions) Build() *discordgo.MessageAllowedMentions {
 	return result
 }
 
+// HasChanges returns true if any command was added, changed or removed.
+func (self *DiscordCommandSyncReport) HasChanges() bool {
+	if self == nil { return false }
+	return len(self.Added) > 0 || len(self.Changed) > 0 || len(self.Removed) > 0
+}
+
+// String returns a readable summary of the report, listing the names of the affected commands.
+func (self *DiscordCommandSyncReport) String() string {
+	if self == nil { return "" }
+
+	scope := "global"
+	if self.GuildId != "" {
+		scope = "guild " + self.GuildId
+	}
+
+	names := func (commands []*discordgo.ApplicationCommand) string {
+		return strings.Join(convertAll(commands, func (command *discordgo.ApplicationCommand) string {
+			return command.Name
+		}), ", ")
+	}
+
+	return fmt.Sprintf("%s commands: added [%s], changed [%s], removed [%s], unchanged %d, applied %t",
+		scope, names(self.Added), names(self.Changed), names(self.Removed), len(self.Unchanged), self.Applied)
+}
+
 // convertAll maps a slice of [T] into a slice of [U] using a mapper function.
 func convertAll[T any, U any](list []T, fn func(T)U) []U {
 	result := make([]U, len(list))
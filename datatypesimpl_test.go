@@ -0,0 +1,21 @@
+package ktncordgo
+
+import (
+	"testing"
+)
+
+func TestMessageSendWebhookEdit(t *testing.T) {
+	edit := (&DiscordMessageSend{Content: "done"}).buildWebhookEdit()
+	if edit.Content == nil || *edit.Content != "done" {
+		t.Errorf("expected the content to be set, got %v", edit.Content)
+	}
+
+	if edit.Embeds != nil || edit.Components != nil {
+		t.Errorf("expected embeds and components to be left alone, got %v and %v", edit.Embeds, edit.Components)
+	}
+
+	edit = (&DiscordMessageSend{Embeds: []*DiscordEmbed{{Title: "Result"}}}).buildWebhookEdit()
+	if edit.Content != nil || edit.Embeds == nil || len(*edit.Embeds) != 1 {
+		t.Errorf("expected only the embeds to be set, got %+v", edit)
+	}
+}
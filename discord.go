@@ -3,59 +3,229 @@ package ktncordgo
 import (
 	"fmt"
 	"log"
+	"maps"
+	"net/http"
+	"net/url"
 
 	"github.com/bwmarrin/discordgo"
 )
 
+// DefaultDiscordIntents are the intents used by [CreateDiscordUnit].
+const DefaultDiscordIntents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
+
 // CreateDiscordUnit takes a discord token and creates a [DiscordUnit] instance.
 //
 // Returns the create instance on success, otherwise an error.
 //
 // See: [DiscordUnit]
+// See: [CreateDiscordUnitWithOptions]
+// See: [DefaultDiscordIntents]
+func CreateDiscordUnit(token string) (IDiscordUnit, error) {
+	return CreateDiscordUnitWithOptions(token, DiscordUnitOptions{})
+}
+
+// CreateDiscordUnitWithOptions takes a discord token and options, and creates a [DiscordUnit] instance.
+//
+// Parameters:
+//   token - The bot token.
+//   options - The session, logging and command registration options. Zero values keep the defaults.
+//
+// Returns the create instance on success, otherwise an error.
+//
+// See: [DiscordUnitOptions]
 // See: [discordgo.Session]
 // See: [discordgo.Identify]
 // See: [discordgo.Intents]
-func CreateDiscordUnit(token string) (IDiscordUnit, error) {
+func CreateDiscordUnitWithOptions(token string, options DiscordUnitOptions) (IDiscordUnit, error) {
 	session, err := discordgo.New("Bot " + token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discord session: %w", err)
 	}
 
-	session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
+	session.Identify.Intents = DefaultDiscordIntents
+	if options.Intents != 0 {
+		session.Identify.Intents = options.Intents
+	}
+
+	if options.Presence != nil {
+		session.Identify.Presence = *options.Presence
+	}
+
+	if options.ShardCount > 0 {
+		session.ShardID = options.ShardId
+		session.ShardCount = options.ShardCount
+	}
 
-	return &DiscordUnit{session: session}, nil
+	if options.HTTPClient != nil {
+		session.Client = options.HTTPClient
+	}
+
+	if options.BaseURL != "" {
+		base, err := url.Parse(options.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse base URL: %w", err)
+		}
+
+		client := *session.Client
+		next := client.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+
+		client.Transport = &baseURLTransport{base: base, next: next}
+		session.Client = &client
+	}
+
+	if options.State != nil {
+		session.StateEnabled = !options.State.Disabled
+		session.State.MaxMessageCount = options.State.MaxMessageCount
+		session.State.TrackChannels = options.State.TrackChannels
+		session.State.TrackThreads = options.State.TrackThreads
+		session.State.TrackEmojis = options.State.TrackEmojis
+		session.State.TrackStickers = options.State.TrackStickers
+		session.State.TrackMembers = options.State.TrackMembers
+		session.State.TrackThreadMembers = options.State.TrackThreadMembers
+		session.State.TrackRoles = options.State.TrackRoles
+		session.State.TrackVoice = options.State.TrackVoice
+		session.State.TrackPresences = options.State.TrackPresences
+	}
+
+	unit := &DiscordUnit{
+		session: session,
+		logger: options.Logger,
+		errorSink: options.ErrorSink,
+		errorReply: options.ErrorReply,
+		autoDefer: options.AutoDefer,
+		autoDeferFlags: options.AutoDeferFlags,
+		inferIntents: options.InferIntents,
+		strictIntents: options.StrictIntents,
+		baseIntents: session.Identify.Intents | discordgo.IntentsGuilds,
+		privilegedIntents: options.PrivilegedIntents,
+		devGuildId: options.DevGuildId,
+		guildCleanup: options.CleanupGuildCommands,
+		commandSync: options.CommandSync,
+	}
+
+	for guildId, commands := range options.GuildCommands {
+		unit.SetGuildCommands(guildId, commands)
+	}
+
+	return unit, nil
 }
 
 // Start takes a slice of commands, opens the session, and registers the commands with discord.
+// Commands registered on the attached [CommandRouter] with [CommandRouter.Command] are registered as well,
+// unless a command with the same name is passed in.
+//
+// Commands are synced rather than recreated, so discord is only updated when the set of commands changed.
+// If a development guild is set, the commands are registered there instead, and guild command sets are synced as well.
+// By default commands are only created, the command sync mode of [DiscordUnitOptions] can make registration
+// remove stale commands, turn it into a dry run, or disable it.
+// If registration fails, the session is closed again.
+// The session identifies with the intents of [DiscordUnit.ComputeIntents].
 //
 // Parameters:
-//   commands - a slice of [discordgo.ApplicationCommand] references.
+//   commands - a slice of [discordgo.ApplicationCommand] references, e.g. from [BuildCommand].
 //
 // Returns an error on failure.
 //
 // See: [discordgo.Session.Open]
-// See: [discordgo.Session.ApplicationCommandCreate]
+// See: [DiscordUnit.ComputeIntents]
+// See: [DiscordUnit.SyncCommands]
+// See: [DiscordUnit.SetDevGuild]
+// See: [DiscordUnit.SetGuildCommands]
+// See: [CommandRouter.ApplicationCommands]
 func (self *DiscordUnit) Start(commands []*discordgo.ApplicationCommand) error {
-	err := self.session.Open()
+	intents, err := self.ComputeIntents()
+	if err != nil {
+		return err
+	}
+
+	self.session.Identify.Intents = intents
+
+	err = self.session.Open()
 	if err != nil {
 		return fmt.Errorf("failed to open session: %w", err)
 	}
 
-	for cmd := range commands {
-		_, err = self.session.ApplicationCommandCreate(self.session.State.User.ID, "", commands[cmd])
+	if self.commandSync == DiscordCommandSyncDisabled {
+		return nil
+	}
 
-		if err != nil {
-			log.Printf("failed to create slash command: %v", err)
+	dryRun := self.commandSync == DiscordCommandSyncDryRun
+	reports, err := self.syncAll(commands, DiscordCommandSyncOptions{
+		DryRun: dryRun,
+		CreateOnly: self.commandSync == DiscordCommandSyncCreate,
+	})
+
+	for _, report := range reports {
+		if report.Applied {
+			self.logf("Synced %s\n", report)
+		} else if dryRun && report.HasChanges() {
+			self.logf("Dry run, planned %s\n", report)
 		}
 	}
 
+	if err != nil {
+		self.session.Close()
+		return err
+	}
+
 	return nil
 }
 
+// mergeRouterCommands appends the definitions generated by the attached router to a slice of commands.
+func (self *DiscordUnit) mergeRouterCommands(commands []*discordgo.ApplicationCommand) ([]*discordgo.ApplicationCommand, error) {
+	router := self.router.Load()
+	if router == nil {
+		return commands, nil
+	}
+
+	generated, err := router.ApplicationCommands()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate router commands: %w", err)
+	}
+
+	result := make([]*discordgo.ApplicationCommand, 0, len(commands) + len(generated))
+	result = append(result, commands...)
+
+	for _, command := range generated {
+		exists := false
+		for _, other := range commands {
+			if other.Name == command.Name && other.Type == command.Type {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			result = append(result, command)
+		}
+	}
+
+	return result, nil
+}
+
 // Stop stops the discord session.
+// If guild command cleanup is enabled, the commands this unit synced to each guild are removed first.
 //
 // See: [discordgo.Session.Close]
+// See: [DiscordUnit.SetGuildCommandCleanup]
 func (self *DiscordUnit) Stop() {
+	self.syncMutex.Lock()
+	var synced map[string][]*discordgo.ApplicationCommand
+	if self.guildCleanup {
+		synced = maps.Clone(self.syncedGuilds)
+	}
+	self.syncMutex.Unlock()
+
+	for guildId, commands := range synced {
+		err := self.cleanupGuildCommands(guildId, commands)
+		if err != nil {
+			self.logf("Failed to clean up commands of guild '%s': %v\n", guildId, err)
+		}
+	}
+
 	self.session.Close()
 }
 
@@ -80,6 +250,24 @@ func (self *DiscordUnit) Session() *discordgo.Session {
 	return self.session
 }
 
+// Logger returns the logger used by the [DiscordUnit] and its units.
+//
+// Returns the configured [log.Logger], or the standard logger if none was set.
+//
+// See: [DiscordUnitOptions]
+func (self *DiscordUnit) Logger() *log.Logger {
+	if self.logger == nil {
+		return log.Default()
+	}
+
+	return self.logger
+}
+
+// logf prints a message to the logger of the [DiscordUnit].
+func (self *DiscordUnit) logf(format string, args ...any) {
+	self.Logger().Printf(format, args...)
+}
+
 // NewInteractionUnit creates a new [DiscordInteractionUnit] reference using the current [DiscordUnit] instance as the parent object.
 //
 // Parameters:
@@ -90,38 +278,214 @@ func (self *DiscordUnit) Session() *discordgo.Session {
 // See: [DiscordInteractionUnit]
 func (self *DiscordUnit) NewInteractionUnit(interaction *discordgo.InteractionCreate) IDiscordInteractionUnit {
 	return &DiscordInteractionUnit {
-		discord: self,
-		interaction: interaction,
+		DiscordBaseInteractionUnit: DiscordBaseInteractionUnit{
+			discord: self,
+			interaction: interaction,
+		},
 	}
 }
 
 // OnSlashCommand registers an event handler for Slash Commands.
+// Other interactions, like button clicks or context menu commands, are not passed to the handler.
 //
 // Parameters:
 //   callback - The callback handler for the slash command event.
-func (self *DiscordUnit) OnSlashCommand(callback func(IDiscordUnit, IDiscordInteractionUnit)) {
-	self.session.AddHandler(func (inSession *discordgo.Session, inInteraction *discordgo.InteractionCreate) {
-		session := NewDiscordUnit(inSession)
-		interaction := session.NewInteractionUnit(inInteraction)
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordSubscription]
+func (self *DiscordUnit) OnSlashCommand(callback func(IDiscordUnit, IDiscordInteractionUnit)) IDiscordSubscription {
+	return self.addHandler(self.slashCommandHandler(func (interaction IDiscordInteractionUnit) error {
+		callback(self, interaction)
+		return nil
+	}), false)
+}
 
-		callback(session, interaction)
-	})
+// OnceSlashCommand registers an event handler for the next Slash Command only.
+//
+// Parameters:
+//   callback - The callback handler for the slash command event.
+//
+// Returns the subscription handle, used to remove the handler before it fires.
+//
+// See: [IDiscordSubscription]
+func (self *DiscordUnit) OnceSlashCommand(callback func(IDiscordUnit, IDiscordInteractionUnit)) IDiscordSubscription {
+	return self.addHandler(self.slashCommandHandler(func (interaction IDiscordInteractionUnit) error {
+		callback(self, interaction)
+		return nil
+	}), true)
+}
+
+// slashCommandHandler wraps a slash command callback into an event listener.
+func (self *DiscordUnit) slashCommandHandler(callback IDiscordCommandFn) *discordListener {
+	return &discordListener{
+		eventType: interactionCreateType,
+		match: func (native any) bool {
+			return isChatCommand(native.(*discordgo.InteractionCreate))
+		},
+		handle: func (event *DiscordEvent) error {
+			return callback(event.interaction.(*DiscordInteractionUnit))
+		},
+	}
 }
 
 // OnMessageCreate registers an event handler for Channel Chat Messages.
 //
 // Parameters:
 //   callback - The callback handler for the message create event.
-func (self *DiscordUnit) OnMessageCreate(callback func (IDiscordUnit, IDiscordMessageUnit)) {
-	self.session.AddHandler(func (inSession *discordgo.Session, inMessage *discordgo.MessageCreate) {
-		var session *DiscordUnit = &DiscordUnit{session: inSession}
-		var message *DiscordMessageUnit = &DiscordMessageUnit{
-			discord: session,
-			message: inMessage.Message,
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordSubscription]
+func (self *DiscordUnit) OnMessageCreate(callback func (IDiscordUnit, IDiscordMessageUnit)) IDiscordSubscription {
+	return self.addHandler(self.messageCreateHandler(callback), false)
+}
+
+// OnceMessageCreate registers an event handler for the next Channel Chat Message only.
+//
+// Parameters:
+//   callback - The callback handler for the message create event.
+//
+// Returns the subscription handle, used to remove the handler before it fires.
+//
+// See: [IDiscordSubscription]
+func (self *DiscordUnit) OnceMessageCreate(callback func (IDiscordUnit, IDiscordMessageUnit)) IDiscordSubscription {
+	return self.addHandler(self.messageCreateHandler(callback), true)
+}
+
+// messageCreateHandler wraps a message callback into an event listener.
+func (self *DiscordUnit) messageCreateHandler(callback func (IDiscordUnit, IDiscordMessageUnit)) *discordListener {
+	return &discordListener{
+		eventType: messageCreateType,
+		handle: func (event *DiscordEvent) error {
+			callback(self, event.message)
+			return nil
+		},
+	}
+}
+
+// OnEvent registers a raw discordgo event handler, taking part in intent inference.
+// The handler runs through the middleware chain as a [DiscordEventRaw] event, unless other handlers share the event.
+//
+// Parameters:
+//   handler - A discordgo handler function, e.g. func(*discordgo.Session, *discordgo.GuildMemberAdd).
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [discordgo.Session.AddHandler]
+// See: [DiscordUnit.RequiredIntents]
+func (self *DiscordUnit) OnEvent(handler any) IDiscordSubscription {
+	return self.addHandler(self.rawEventHandler(handler), false)
+}
+
+// OnceEvent registers a raw discordgo event handler for the next matching event only.
+//
+// Parameters:
+//   handler - A discordgo handler function, e.g. func(*discordgo.Session, *discordgo.GuildMemberAdd).
+//
+// Returns the subscription handle, used to remove the handler before it fires.
+//
+// See: [DiscordUnit.OnEvent]
+func (self *DiscordUnit) OnceEvent(handler any) IDiscordSubscription {
+	return self.addHandler(self.rawEventHandler(handler), true)
+}
+
+// Router returns the [CommandRouter] attached to the [DiscordUnit].
+// If no router is attached yet, an empty one is created and attached.
+//
+// Returns the attached [CommandRouter] reference.
+//
+// See: [CommandRouter]
+// See: [DiscordUnit.SetRouter]
+func (self *DiscordUnit) Router() *CommandRouter {
+	self.routerMutex.Lock()
+	defer self.routerMutex.Unlock()
+
+	router := self.router.Load()
+	if router == nil {
+		router = NewCommandRouter()
+	}
+
+	self.setRouterLocked(router)
+	return router
+}
+
+// SetRouter attaches a [CommandRouter] to the [DiscordUnit], which then receives all slash commands,
+// context menu commands and autocomplete interactions.
+// The router runs through the middleware chain, and errors returned by the router are logged.
+// Context menu commands registered before, through [DiscordUnit.OnUserCommand], [DiscordUnit.OnMessageCommand]
+// or the previous router, are carried over unless the new router handles the same command,
+// so SetRouter may be called before or after registering them.
+//
+// Parameters:
+//   router - The router to attach, or nil to stop routing commands, dropping the registered context menu commands.
+//
+// See: [CommandRouter.Dispatch]
+// See: [CommandRouter.DispatchAutocomplete]
+// See: [DiscordUnit.OnSlashCommand]
+func (self *DiscordUnit) SetRouter(router *CommandRouter) {
+	self.routerMutex.Lock()
+	defer self.routerMutex.Unlock()
+
+	self.setRouterLocked(router)
+}
+
+// setRouterLocked attaches a router, installing the routing handlers once. The caller must hold the router lock.
+// The context menu commands of a replaced router are carried over to the new one.
+func (self *DiscordUnit) setRouterLocked(router *CommandRouter) {
+	previous := self.router.Swap(router)
+
+	if router == nil {
+		return
+	}
+
+	if previous != nil && previous != router {
+		router.adoptContextRoutes(previous)
+	}
+
+	self.installContextRouteLocked()
+
+	if self.routesInstalled {
+		return
+	}
+
+	self.routesInstalled = true
+
+	self.addHandler(self.slashCommandHandler(func (interaction IDiscordInteractionUnit) error {
+		router := self.router.Load()
+		if router == nil {
+			return nil
 		}
 
-		callback(session, message)
-	})
+		return router.Dispatch(interaction)
+	}), false)
+
+	self.addHandler(self.autocompleteHandler(func (interaction IDiscordAutocompleteUnit) error {
+		router := self.router.Load()
+		if router == nil {
+			return nil
+		}
+
+		return router.DispatchAutocomplete(interaction)
+	}), false)
+}
+
+// installContextRouteLocked installs the context menu routing handler once. The caller must hold the router lock.
+func (self *DiscordUnit) installContextRouteLocked() {
+	if self.contextInstalled {
+		return
+	}
+
+	self.contextInstalled = true
+
+	self.addHandler(self.contextMenuHandler(func (interaction IDiscordContextMenuUnit) error {
+		router := self.router.Load()
+		if router == nil {
+			return nil
+		}
+
+		return router.DispatchContextMenu(interaction)
+	}), false)
 }
 
 // GetUser finds and returns a user given a snowflake ID.
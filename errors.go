@@ -0,0 +1,164 @@
+package ktncordgo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoHandler is returned by [CommandRouter.Dispatch] when no handler matches the command path.
+var ErrNoHandler = errors.New("no handler registered for command")
+
+// ErrInvalidBindTarget is returned by [DiscordInteractionUnit.Bind] when the destination is not a pointer to a struct of bindable fields.
+var ErrInvalidBindTarget = errors.New("bind target must be a non-nil pointer to a struct of bindable fields")
+
+// ErrMissingIntents is returned by [DiscordUnit.ComputeIntents] in strict mode when registered handlers need intents that are not enabled.
+var ErrMissingIntents = errors.New("registered handlers need intents that are not enabled")
+
+// ErrInteractionExpired is returned when responding to an interaction whose token is no longer valid.
+// The initial response must be sent within 3 seconds, follow-ups and edits within 15 minutes.
+var ErrInteractionExpired = errors.New("interaction token has expired")
+
+// ErrAlreadyResponded is returned when sending the initial response of an interaction that was already responded to or deferred.
+//
+// See: [DiscordBaseInteractionUnit.Respond]
+var ErrAlreadyResponded = errors.New("interaction has already been responded to")
+
+// ErrDeferredFlags is returned when sending a deferred reply with flags the deferral did not set, as they cannot be changed by editing.
+//
+// See: [DiscordBaseInteractionUnit.DeferReply]
+var ErrDeferredFlags = errors.New("flags of a deferred reply cannot be changed")
+
+// ErrTooManyChoices is returned by [DiscordAutocompleteUnit.RespondChoices] when more than [DiscordMaxChoices] choices are passed.
+var ErrTooManyChoices = errors.New("too many autocomplete choices")
+
+// ErrMissingPermissions is returned by moderation actions when the bot lacks the permission for the action.
+var ErrMissingPermissions = errors.New("bot is missing permissions for this action")
+
+// ErrRoleHierarchy is returned by moderation actions when the target's highest role is not below the bot's highest role.
+// Discord reports this with the same code as missing permissions, so the two are told apart by the bot's permissions.
+var ErrRoleHierarchy = errors.New("target is not below the bot in the role hierarchy")
+
+// ErrMemberNotFound is returned by [DiscordGuildUnit.ResolveMember] when no member matches the query.
+var ErrMemberNotFound = errors.New("no member matches the query")
+
+// DiscordArgumentError is returned when an argument is outside the range discord accepts, before any request is sent.
+// Use [errors.As] to check for it.
+type DiscordArgumentError struct {
+	Argument string
+	Value any
+	Reason string
+}
+
+// Error returns the error message.
+func (self *DiscordArgumentError) Error() string {
+	return fmt.Sprintf("invalid argument '%s' (%v): %s", self.Argument, self.Value, self.Reason)
+}
+
+// DiscordOptionErrorKind describes why an option could not be bound.
+//
+// See: [DiscordOptionError]
+type DiscordOptionErrorKind int
+
+const (
+	DiscordOptionErrorMissing	DiscordOptionErrorKind = iota
+	DiscordOptionErrorType
+	DiscordOptionErrorValue
+	DiscordOptionErrorUnresolved
+)
+
+// String returns a short description of the error kind.
+func (self DiscordOptionErrorKind) String() string {
+	switch self {
+	case DiscordOptionErrorMissing:
+		return "missing required value"
+	case DiscordOptionErrorType:
+		return "mismatched type"
+	case DiscordOptionErrorValue:
+		return "invalid value"
+	case DiscordOptionErrorUnresolved:
+		return "unresolved value"
+	}
+
+	return "unknown error"
+}
+
+// DiscordOptionError is returned by [DiscordInteractionUnit.Bind] when an option cannot be bound.
+// Use [errors.As] to check for it.
+//
+// See: [DiscordOptionErrorKind]
+type DiscordOptionError struct {
+	Option string
+	Kind DiscordOptionErrorKind
+	Err error
+}
+
+// Error returns the error message.
+func (self *DiscordOptionError) Error() string {
+	if self.Err != nil {
+		return fmt.Sprintf("option '%s': %s: %v", self.Option, self.Kind, self.Err)
+	}
+
+	return fmt.Sprintf("option '%s': %s", self.Option, self.Kind)
+}
+
+// Unwrap returns the underlying error, if any.
+func (self *DiscordOptionError) Unwrap() error {
+	return self.Err
+}
+
+// Message returns a message suitable for showing to the user that ran the command.
+func (self *DiscordOptionError) Message() string {
+	switch self.Kind {
+	case DiscordOptionErrorMissing:
+		return fmt.Sprintf("The option `%s` is required.", self.Option)
+	case DiscordOptionErrorUnresolved:
+		return fmt.Sprintf("The value given for `%s` could not be found.", self.Option)
+	}
+
+	return fmt.Sprintf("The value given for `%s` is not valid.", self.Option)
+}
+
+// HandlerError is reported to the error sink when an event handler returns an error or panics.
+//
+// See: [DiscordUnit.SetErrorSink]
+type HandlerError struct {
+	EventType DiscordEventType
+	CommandName string
+	GuildId string
+	ChannelId string
+	UserId string
+	Err error
+	Panic any
+	Stack []byte
+}
+
+// Error returns the error message.
+func (self *HandlerError) Error() string {
+	name := string(self.EventType)
+	if self.CommandName != "" {
+		name = fmt.Sprintf("%s '%s'", self.EventType, self.CommandName)
+	}
+
+	if self.Panic != nil {
+		return fmt.Sprintf("%s handler panicked: %v", name, self.Panic)
+	}
+
+	return fmt.Sprintf("%s handler failed: %v", name, self.Err)
+}
+
+// Unwrap returns the error returned by the handler, or the recovered value if it is an error.
+func (self *HandlerError) Unwrap() error {
+	return self.Err
+}
+
+// AmbiguousMemberError is returned by [DiscordGuildUnit.ResolveMember] when several members match the query equally well.
+// Use [errors.As] to check for it and list the matches to the user.
+type AmbiguousMemberError struct {
+	Query string
+	Matches []IDiscordMemberUnit
+}
+
+// Error returns the error message.
+func (self *AmbiguousMemberError) Error() string {
+	return fmt.Sprintf("'%s' matches %d members", self.Query, len(self.Matches))
+}
@@ -0,0 +1,436 @@
+package ktncordgo
+
+import (
+	"errors"
+	"reflect"
+	"runtime/debug"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// interactionCreateType and messageCreateType are the discordgo event types of the typed listeners.
+var (
+	interactionCreateType = reflect.TypeOf(&discordgo.InteractionCreate{})
+	messageCreateType = reflect.TypeOf(&discordgo.MessageCreate{})
+)
+
+// Discord returns the parent [DiscordUnit] object, the root of [ktncordgo].
+//
+// See: [DiscordUnit]
+func (self *DiscordEvent) Discord() IDiscordUnit {
+	return self.discord
+}
+
+// Native returns the underlying discordgo event, e.g. a [discordgo.InteractionCreate] reference.
+func (self *DiscordEvent) Native() any {
+	return self.native
+}
+
+// Type returns the kind of the event.
+//
+// See: [DiscordEventType]
+func (self *DiscordEvent) Type() DiscordEventType {
+	return self.eventType
+}
+
+// Name returns the command path for slash commands, the custom ID for components and modals, otherwise an empty string.
+//
+// See: [DiscordInteractionUnit.CommandPath]
+// See: [DiscordComponentUnit.CustomId]
+func (self *DiscordEvent) Name() string {
+	if self.interaction == nil {
+		return ""
+	}
+
+	native := self.interaction.Native()
+
+	switch native.Type {
+	case discordgo.InteractionApplicationCommand, discordgo.InteractionApplicationCommandAutocomplete:
+		return commandPath(native.ApplicationCommandData())
+	case discordgo.InteractionMessageComponent:
+		return native.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return native.ModalSubmitData().CustomID
+	}
+
+	return ""
+}
+
+// Interaction returns the interaction of the event, or nil if the event is not an interaction.
+// Use a type assertion to reach the specific unit, e.g. [IDiscordInteractionUnit] or [IDiscordComponentUnit].
+//
+// See: [DiscordBaseInteractionUnit]
+func (self *DiscordEvent) Interaction() IDiscordBaseInteractionUnit {
+	return self.interaction
+}
+
+// Message returns the message of the event, or nil if the event is not a message event.
+//
+// See: [DiscordMessageUnit]
+func (self *DiscordEvent) Message() IDiscordMessageUnit {
+	return self.message
+}
+
+// GuildId returns the ID of the guild the event happened in, or an empty string.
+func (self *DiscordEvent) GuildId() string {
+	if self.interaction != nil {
+		return self.interaction.Native().GuildID
+	}
+
+	if self.message != nil {
+		return self.message.Native().GuildID
+	}
+
+	return nativeString(self.native, "GuildID")
+}
+
+// ChannelId returns the ID of the channel the event happened in, or an empty string.
+func (self *DiscordEvent) ChannelId() string {
+	if self.interaction != nil {
+		return self.interaction.Native().ChannelID
+	}
+
+	if self.message != nil {
+		return self.message.Native().ChannelID
+	}
+
+	return nativeString(self.native, "ChannelID")
+}
+
+// UserId returns the ID of the user that caused the event, or an empty string.
+func (self *DiscordEvent) UserId() string {
+	if self.interaction != nil {
+		native := self.interaction.Native()
+		if native.Member != nil && native.Member.User != nil {
+			return native.Member.User.ID
+		}
+
+		if native.User != nil {
+			return native.User.ID
+		}
+
+		return ""
+	}
+
+	if self.message != nil {
+		if self.message.Native().Author != nil {
+			return self.message.Native().Author.ID
+		}
+
+		return ""
+	}
+
+	return nativeString(self.native, "UserID")
+}
+
+// Use appends middleware to the handler chain, including for handlers registered before.
+// The chain runs once per incoming event, wrapping all handlers registered for the event.
+// The first middleware added is the outermost, so it runs first and sees the result of all others.
+//
+// Parameters:
+//   middleware - The middleware to append, in order.
+//
+// See: [DiscordMiddleware]
+func (self *DiscordUnit) Use(middleware ...DiscordMiddleware) {
+	self.middlewareMutex.Lock()
+	defer self.middlewareMutex.Unlock()
+
+	self.middleware = append(self.middleware, middleware...)
+}
+
+// SetErrorSink sets the function receiving every error returned by, or panic recovered from, an event handler.
+// By default, handler errors are logged.
+//
+// Parameters:
+//   sink - The error sink, or nil to restore logging.
+//
+// See: [HandlerError]
+func (self *DiscordUnit) SetErrorSink(sink func(*HandlerError)) {
+	self.middlewareMutex.Lock()
+	defer self.middlewareMutex.Unlock()
+
+	self.errorSink = sink
+}
+
+// SetErrorReply sets a generic message sent as an ephemeral reply when an interaction handler fails.
+// The reply is sent once per interaction, even if several handlers fail on it.
+//
+// Parameters:
+//   message - The reply content, or an empty string to not reply.
+func (self *DiscordUnit) SetErrorReply(message string) {
+	self.middlewareMutex.Lock()
+	defer self.middlewareMutex.Unlock()
+
+	self.errorReply = message
+}
+
+// dispatch runs an event through the middleware chain into the final handler,
+// reporting any returned error or recovered panic.
+func (self *DiscordUnit) dispatch(event *DiscordEvent, handler IDiscordHandlerFn) {
+	stopAutoDefer := self.startAutoDefer(event)
+	defer stopAutoDefer()
+
+	defer func () {
+		recovered := recover()
+		if recovered != nil {
+			self.reportError(event, nil, recovered, debug.Stack())
+		}
+	}()
+
+	self.middlewareMutex.RLock()
+	middleware := self.middleware
+	self.middlewareMutex.RUnlock()
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	err := handler(event)
+	if err != nil {
+		self.reportError(event, err, nil, nil)
+	}
+}
+
+// reportError passes a handler failure to the error sink, and replies to the interaction once if configured.
+func (self *DiscordUnit) reportError(event *DiscordEvent, err error, recovered any, stack []byte) {
+	if recoveredErr, ok := recovered.(error); ok {
+		err = recoveredErr
+	}
+
+	handlerErr := &HandlerError{
+		EventType: event.Type(),
+		CommandName: event.Name(),
+		GuildId: event.GuildId(),
+		ChannelId: event.ChannelId(),
+		UserId: event.UserId(),
+		Err: err,
+		Panic: recovered,
+		Stack: stack,
+	}
+
+	self.middlewareMutex.RLock()
+	sink := self.errorSink
+	reply := self.errorReply
+	self.middlewareMutex.RUnlock()
+
+	if sink != nil {
+		sink(handlerErr)
+	} else if stack != nil {
+		self.logf("%v\n%s\n", handlerErr, stack)
+	} else {
+		self.logf("%v\n", handlerErr)
+	}
+
+	if reply == "" || event.interaction == nil {
+		return
+	}
+
+	unit, ok := event.interaction.(baseInteraction)
+	if !ok || unit.base().interaction.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		return
+	}
+
+	// Several listeners may fail on the same interaction, only the first failure is replied to.
+	if unit.base().errorReplied.Swap(true) {
+		return
+	}
+
+	opts := DiscordMessageSend{
+		Content: reply,
+		Flags: discordgo.MessageFlagsEphemeral,
+	}
+
+	replyErr := ErrAlreadyResponded
+	if unit.base().ResponseState() == DiscordResponseNone {
+		replyErr = unit.base().ReplyOptions(opts)
+	}
+
+	if errors.Is(replyErr, ErrAlreadyResponded) {
+		_, replyErr = unit.base().FollowUpOptions(opts)
+	}
+
+	if replyErr != nil {
+		self.logf("Failed to send error reply: %v\n", replyErr)
+	}
+}
+
+// interactionEvent creates the event of an interaction, used to report errors outside of the middleware chain.
+func interactionEvent(interaction *DiscordInteractionUnit) *DiscordEvent {
+	return &DiscordEvent{
+		discord: interaction.discord,
+		eventType: DiscordEventSlashCommand,
+		interaction: interaction,
+		native: interaction.interaction,
+	}
+}
+
+// rawEventHandler wraps a discordgo event handler into a raw event listener.
+// Handlers that discordgo would not accept are logged and never called.
+func (self *DiscordUnit) rawEventHandler(handler any) *discordListener {
+	value := reflect.ValueOf(handler)
+	if value.Kind() != reflect.Func || value.Type().NumIn() != 2 || value.Type().In(0) != reflect.TypeOf(self.session) {
+		self.logf("Invalid event handler %T, it will never be called\n", handler)
+		return nil
+	}
+
+	return &discordListener{
+		eventType: value.Type().In(1),
+		raw: true,
+		handle: func (event *DiscordEvent) error {
+			value.Call([]reflect.Value{reflect.ValueOf(self.session), reflect.ValueOf(event.native)})
+			return nil
+		},
+	}
+}
+
+// handleEvent is the discordgo handler of the unit, receiving every event.
+// The event is passed through the middleware chain once, into all listeners matching it.
+// The event carries a unit only if a non-raw listener matched, so raw handlers keep full control over responses.
+func (self *DiscordUnit) handleEvent(session *discordgo.Session, native any) {
+	nativeType := reflect.TypeOf(native)
+
+	self.listenerMutex.RLock()
+	listeners := self.listeners
+	self.listenerMutex.RUnlock()
+
+	matched := make([]*discordListener, 0, 1)
+	typed := false
+
+	for _, listener := range listeners {
+		if !nativeType.AssignableTo(listener.eventType) || (listener.match != nil && !listener.match(native)) {
+			continue
+		}
+
+		if listener.once {
+			if !listener.subscription.deactivate() {
+				continue
+			}
+		} else if !listener.subscription.Active() {
+			continue
+		}
+
+		matched = append(matched, listener)
+		typed = typed || !listener.raw
+	}
+
+	if len(matched) == 0 {
+		return
+	}
+
+	event := &DiscordEvent{
+		discord: self,
+		eventType: DiscordEventRaw,
+		native: native,
+	}
+
+	if typed {
+		self.wrapEvent(event)
+	}
+
+	self.dispatch(event, func (IDiscordEvent) error {
+		errs := make([]error, 0, len(matched))
+
+		for _, listener := range matched {
+			errs = append(errs, self.runListener(listener, event))
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// wrapEvent sets the type and unit of an interaction or message event.
+func (self *DiscordUnit) wrapEvent(event *DiscordEvent) {
+	switch native := event.native.(type) {
+	case *discordgo.InteractionCreate:
+		switch native.Type {
+		case discordgo.InteractionApplicationCommand:
+			if isChatCommand(native) {
+				event.eventType = DiscordEventSlashCommand
+				event.interaction = &DiscordInteractionUnit{
+					DiscordBaseInteractionUnit: DiscordBaseInteractionUnit{
+						discord: self,
+						interaction: native,
+					},
+				}
+			} else {
+				event.eventType = DiscordEventContextMenu
+				event.interaction = &DiscordContextMenuUnit{
+					DiscordBaseInteractionUnit: DiscordBaseInteractionUnit{
+						discord: self,
+						interaction: native,
+					},
+				}
+			}
+		case discordgo.InteractionMessageComponent:
+			event.eventType = DiscordEventComponent
+			event.interaction = &DiscordComponentUnit{
+				DiscordBaseInteractionUnit: DiscordBaseInteractionUnit{
+					discord: self,
+					interaction: native,
+				},
+			}
+		case discordgo.InteractionModalSubmit:
+			event.eventType = DiscordEventModalSubmit
+			event.interaction = &DiscordModalUnit{
+				DiscordBaseInteractionUnit: DiscordBaseInteractionUnit{
+					discord: self,
+					interaction: native,
+				},
+			}
+		case discordgo.InteractionApplicationCommandAutocomplete:
+			event.eventType = DiscordEventAutocomplete
+			event.interaction = &DiscordAutocompleteUnit{
+				DiscordBaseInteractionUnit: DiscordBaseInteractionUnit{
+					discord: self,
+					interaction: native,
+				},
+			}
+		}
+	case *discordgo.MessageCreate:
+		event.eventType = DiscordEventMessageCreate
+		event.message = &DiscordMessageUnit{
+			discord: self,
+			message: native.Message,
+		}
+	}
+}
+
+// runListener runs a single listener, reporting a recovered panic so the other listeners still run.
+func (self *DiscordUnit) runListener(listener *discordListener, event *DiscordEvent) (err error) {
+	defer func () {
+		recovered := recover()
+		if recovered != nil {
+			self.reportError(event, nil, recovered, debug.Stack())
+		}
+	}()
+
+	return listener.handle(event)
+}
+
+// nativeString reads a string field of a discordgo event struct, including embedded fields.
+func nativeString(native any, name string) string {
+	value := reflect.ValueOf(native)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+
+	structField, ok := value.Type().FieldByName(name)
+	if !ok {
+		return ""
+	}
+
+	field, err := value.FieldByIndexErr(structField.Index)
+	if err != nil || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
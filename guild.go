@@ -1,8 +1,9 @@
 package ktncordgo
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"iter"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -117,37 +118,99 @@ func (self *DiscordGuildUnit) GetChannel(channelId string) (IDiscordChannelUnit,
 	return nil, fmt.Errorf("failed to fetch channel: channel not found")
 }
 
-// GetMember Count returns the number of members in the discord guild.
-// Note: This is an iterative process, be vary of usage in larger servers.
+// GetMember finds and returns a member of the discord guild.
+//
+// Parameters:
+//   userId - The ID of the user.
+//
+// Returns the member if found, otherwise an error.
+//
+// See: [DiscordMemberUnit]
+// See: [discordgo.Session.GuildMember]
+func (self *DiscordGuildUnit) GetMember(userId string) (IDiscordMemberUnit, error) {
+	member, err := self.discord.session.GuildMember(self.guild.ID, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch guild member: %w", err)
+	}
+
+	member.GuildID = self.guild.ID
+
+	return &DiscordMemberUnit{
+		discord: self.discord,
+		member: member,
+	}, nil
+}
+
+// GetMemberCount returns the number of members in the discord guild, without scanning the member list.
+// The count is taken from the state when the guild is tracked, and is otherwise fetched with the guild's
+// approximate counts, so it may lag behind recent joins and leaves.
 //
 // Returns number of members if successful, otherwise an error.
 //
-// See: [discordgo.Session.GuildMembers]
+// See: [discordgo.Guild.MemberCount]
+// See: [discordgo.Guild.ApproximateMemberCount]
+// See: [discordgo.Session.GuildWithCounts]
 func (self *DiscordGuildUnit) GetMemberCount() (int, error) {
-	var count int = 0
-	var last string = ""
-
-	log.Printf("Scanning member count for '%s'[%s]\n", self.guild.Name, self.guild.ID)
+	if guild, err := self.discord.session.State.Guild(self.guild.ID); err == nil && guild.MemberCount > 0 {
+		return guild.MemberCount, nil
+	}
 
-	for {
-		membs, err := self.discord.session.GuildMembers(self.guild.ID, last, 1000)
-		if err != nil {
-			return 0, err
-		}
+	guild, err := self.discord.session.GuildWithCounts(self.guild.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch member count: %w", err)
+	}
 
-		next := len(membs)
-		count += next
+	return guild.ApproximateMemberCount, nil
+}
 
-		log.Printf("New count for [%s]: %d\n", self.guild.ID, count)
+// Members iterates over every member of the discord guild, fetching pages of 1000 members as needed.
+// Stopping the iteration early stops fetching further pages.
+// A fetch error, including cancellation of the context, is yielded once and ends the iteration.
+//
+// Parameters:
+//   ctx - The context cancelling the iteration.
+//
+// See: [DiscordMemberUnit]
+// See: [discordgo.Session.GuildMembers]
+func (self *DiscordGuildUnit) Members(ctx context.Context) iter.Seq2[IDiscordMemberUnit, error] {
+	return guildMembers(ctx, self.discord, self.guild.ID)
+}
 
-		if next < 1000 {
-			break
+// guildMembers iterates over the members of a guild, fetching them page by page.
+func guildMembers(ctx context.Context, discord *DiscordUnit, guildId string) iter.Seq2[IDiscordMemberUnit, error] {
+	return func (yield func(IDiscordMemberUnit, error) bool) {
+		var last string = ""
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			membs, err := discord.session.GuildMembers(guildId, last, 1000, discordgo.WithContext(ctx))
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to fetch guild members: %w", err))
+				return
+			}
+
+			for _, member := range membs {
+				member.GuildID = guildId
+
+				unit := &DiscordMemberUnit{
+					discord: discord,
+					member: member,
+				}
+
+				if !yield(unit, nil) {
+					return
+				}
+			}
+
+			if len(membs) < 1000 {
+				return
+			}
+
+			last = membs[len(membs) - 1].User.ID
 		}
-
-		last = membs[len(membs) - 1].User.ID
 	}
-
-	log.Printf("Got member count for [%s]: %d\n", self.guild.ID, count)
-
-	return count, nil
 }
@@ -0,0 +1,120 @@
+package ktncordgo
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// discordEpoch is the first millisecond of 2015, the start of discord snowflake timestamps.
+const discordEpoch = 1420070400000
+
+// History iterates over the messages of the channel, fetching pages of 100 messages as needed.
+// Stopping the iteration early stops fetching further pages, and rate limits are waited out by discordgo.
+// A fetch error, including cancellation of the context, is yielded once and ends the iteration.
+//
+// Parameters:
+//   ctx - The context cancelling the iteration.
+//   opts - The direction, start, limit and stop condition of the iteration.
+//
+// See: [DiscordHistoryOptions]
+// See: [discordgo.Session.ChannelMessages]
+func (self *DiscordChannelUnit) History(ctx context.Context, opts DiscordHistoryOptions) iter.Seq2[IDiscordMessageUnit, error] {
+	return func (yield func(IDiscordMessageUnit, error) bool) {
+		forward := opts.Direction == DiscordHistoryForward
+
+		cursor := opts.StartId
+		if cursor == "" && !opts.StartTime.IsZero() {
+			cursor = timeSnowflake(opts.StartTime)
+		} else if cursor == "" && forward {
+			cursor = "0"
+		}
+
+		count := 0
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			pageSize := 100
+			if opts.Limit > 0 && opts.Limit - count < pageSize {
+				pageSize = opts.Limit - count
+			}
+
+			var messages []*discordgo.Message
+			var err error
+
+			if forward {
+				messages, err = self.discord.session.ChannelMessages(self.channel.ID, pageSize, "", cursor, "", discordgo.WithContext(ctx))
+			} else {
+				messages, err = self.discord.session.ChannelMessages(self.channel.ID, pageSize, cursor, "", "", discordgo.WithContext(ctx))
+			}
+
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to fetch channel history: %w", err))
+				return
+			}
+
+			if len(messages) == 0 {
+				return
+			}
+
+			// Pages always come newest first.
+			if forward {
+				slices.Reverse(messages)
+			}
+
+			for _, message := range messages {
+				unit := self.wrapMessage(message)
+
+				if opts.Stop != nil && opts.Stop(unit) {
+					return
+				}
+
+				if !yield(unit, nil) {
+					return
+				}
+
+				count++
+				if opts.Limit > 0 && count >= opts.Limit {
+					return
+				}
+			}
+
+			if len(messages) < pageSize {
+				return
+			}
+
+			cursor = messages[len(messages) - 1].ID
+		}
+	}
+}
+
+// wrapMessage wraps a message of the channel, which discord sends without the guild ID.
+func (self *DiscordChannelUnit) wrapMessage(message *discordgo.Message) IDiscordMessageUnit {
+	if message.GuildID == "" {
+		message.GuildID = self.channel.GuildID
+	}
+
+	return &DiscordMessageUnit{
+		discord: self.discord,
+		message: message,
+	}
+}
+
+// timeSnowflake returns the smallest snowflake of a point in time, used as a message cursor.
+func timeSnowflake(t time.Time) string {
+	milliseconds := t.UnixMilli() - discordEpoch
+	if milliseconds < 0 {
+		return "0"
+	}
+
+	return strconv.FormatInt(milliseconds << 22, 10)
+}
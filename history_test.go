@@ -0,0 +1,61 @@
+package ktncordgo
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestTimeSnowflake(t *testing.T) {
+	// The example snowflake of the discord documentation, created at 2016-04-30 11:18:25.796 UTC.
+	created := time.Date(2016, time.April, 30, 11, 18, 25, 796_000_000, time.UTC)
+
+	tests := []struct {
+		name string
+		time time.Time
+		expected string
+	}{
+		{
+			name: "discord epoch",
+			time: time.UnixMilli(discordEpoch),
+			expected: "0",
+		},
+		{
+			name: "before the epoch",
+			time: time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: "0",
+		},
+		{
+			name: "one millisecond after the epoch",
+			time: time.UnixMilli(discordEpoch + 1),
+			expected: "4194304",
+		},
+		{
+			name: "documentation example",
+			time: created,
+			expected: "175928847298985984",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func (t *testing.T) {
+			if snowflake := timeSnowflake(test.time); snowflake != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, snowflake)
+			}
+		})
+	}
+
+	snowflake := timeSnowflake(created)
+
+	timestamp, err := discordgo.SnowflakeTimestamp(snowflake)
+	if err != nil || !timestamp.Equal(created) {
+		t.Errorf("expected the snowflake to decode to %s, got %s (%v)", created, timestamp, err)
+	}
+
+	id, _ := strconv.ParseInt(snowflake, 10, 64)
+	if id > 175928847299117063 {
+		t.Errorf("expected the smallest snowflake of the millisecond, got %d", id)
+	}
+}
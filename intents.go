@@ -0,0 +1,202 @@
+package ktncordgo
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DiscordPrivilegedIntents are the intents that must be enabled for the bot in the developer portal.
+const DiscordPrivilegedIntents = discordgo.IntentsGuildMembers | discordgo.IntentsGuildPresences | discordgo.IntentsMessageContent
+
+// eventIntents maps discordgo event types to the minimum intents needed to receive them in guilds.
+// Message content is never inferred, so the privileged MessageContent intent has to be opted into with the base intents.
+var eventIntents = map[reflect.Type]discordgo.Intent{
+	reflect.TypeOf(&discordgo.InteractionCreate{}): discordgo.IntentsNone,
+	reflect.TypeOf(&discordgo.GuildCreate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.GuildUpdate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.GuildDelete{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.GuildRoleCreate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.GuildRoleUpdate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.GuildRoleDelete{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.ChannelCreate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.ChannelUpdate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.ChannelDelete{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.ThreadCreate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.ThreadUpdate{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.ThreadDelete{}): discordgo.IntentsGuilds,
+	reflect.TypeOf(&discordgo.GuildMemberAdd{}): discordgo.IntentsGuildMembers,
+	reflect.TypeOf(&discordgo.GuildMemberUpdate{}): discordgo.IntentsGuildMembers,
+	reflect.TypeOf(&discordgo.GuildMemberRemove{}): discordgo.IntentsGuildMembers,
+	reflect.TypeOf(&discordgo.GuildBanAdd{}): discordgo.IntentsGuildBans,
+	reflect.TypeOf(&discordgo.GuildBanRemove{}): discordgo.IntentsGuildBans,
+	reflect.TypeOf(&discordgo.GuildEmojisUpdate{}): discordgo.IntentsGuildEmojis,
+	reflect.TypeOf(&discordgo.GuildIntegrationsUpdate{}): discordgo.IntentsGuildIntegrations,
+	reflect.TypeOf(&discordgo.WebhooksUpdate{}): discordgo.IntentsGuildWebhooks,
+	reflect.TypeOf(&discordgo.InviteCreate{}): discordgo.IntentsGuildInvites,
+	reflect.TypeOf(&discordgo.InviteDelete{}): discordgo.IntentsGuildInvites,
+	reflect.TypeOf(&discordgo.VoiceStateUpdate{}): discordgo.IntentsGuildVoiceStates,
+	reflect.TypeOf(&discordgo.PresenceUpdate{}): discordgo.IntentsGuildPresences,
+	reflect.TypeOf(&discordgo.MessageDelete{}): discordgo.IntentsGuildMessages,
+	reflect.TypeOf(&discordgo.MessageDeleteBulk{}): discordgo.IntentsGuildMessages,
+	reflect.TypeOf(&discordgo.MessageReactionAdd{}): discordgo.IntentsGuildMessageReactions,
+	reflect.TypeOf(&discordgo.MessageReactionRemove{}): discordgo.IntentsGuildMessageReactions,
+	reflect.TypeOf(&discordgo.MessageReactionRemoveAll{}): discordgo.IntentsGuildMessageReactions,
+	reflect.TypeOf(&discordgo.TypingStart{}): discordgo.IntentsGuildMessageTyping,
+	reflect.TypeOf(&discordgo.GuildScheduledEventCreate{}): discordgo.IntentsGuildScheduledEvents,
+	reflect.TypeOf(&discordgo.GuildScheduledEventUpdate{}): discordgo.IntentsGuildScheduledEvents,
+	reflect.TypeOf(&discordgo.GuildScheduledEventDelete{}): discordgo.IntentsGuildScheduledEvents,
+}
+
+// eventAnyIntents maps discordgo event types that are received with any one of several intents.
+// Messages arrive in guilds and in direct messages, so either intent is enough.
+// When inferring, the lowest intent of the set is added, which is the guild intent.
+var eventAnyIntents = map[reflect.Type]discordgo.Intent{
+	reflect.TypeOf(&discordgo.MessageCreate{}): discordgo.IntentsGuildMessages | discordgo.IntentsDirectMessages,
+	reflect.TypeOf(&discordgo.MessageUpdate{}): discordgo.IntentsGuildMessages | discordgo.IntentsDirectMessages,
+}
+
+// intentNames holds readable names of the intents, used in warnings and errors.
+var intentNames = map[discordgo.Intent]string{
+	discordgo.IntentsGuilds: "Guilds",
+	discordgo.IntentsGuildMembers: "GuildMembers",
+	discordgo.IntentsGuildBans: "GuildBans",
+	discordgo.IntentsGuildEmojis: "GuildEmojis",
+	discordgo.IntentsGuildIntegrations: "GuildIntegrations",
+	discordgo.IntentsGuildWebhooks: "GuildWebhooks",
+	discordgo.IntentsGuildInvites: "GuildInvites",
+	discordgo.IntentsGuildVoiceStates: "GuildVoiceStates",
+	discordgo.IntentsGuildPresences: "GuildPresences",
+	discordgo.IntentsGuildMessages: "GuildMessages",
+	discordgo.IntentsGuildMessageReactions: "GuildMessageReactions",
+	discordgo.IntentsGuildMessageTyping: "GuildMessageTyping",
+	discordgo.IntentsDirectMessages: "DirectMessages",
+	discordgo.IntentsDirectMessageReactions: "DirectMessageReactions",
+	discordgo.IntentsDirectMessageTyping: "DirectMessageTyping",
+	discordgo.IntentsMessageContent: "MessageContent",
+	discordgo.IntentsGuildScheduledEvents: "GuildScheduledEvents",
+}
+
+// RequiredIntents returns the minimum intents needed by the handlers registered so far.
+// Events received with any one of several intents, such as messages, contribute their guild intent.
+//
+// See: [DiscordUnit.OnEvent]
+// See: [DiscordUnit.ComputeIntents]
+func (self *DiscordUnit) RequiredIntents() discordgo.Intent {
+	result, anyOf := self.requiredIntents()
+
+	for _, set := range anyOf {
+		result |= preferredIntent(set)
+	}
+
+	return result
+}
+
+// requiredIntents returns the intents all needed by the registered handlers,
+// and the sets of which any one intent is enough.
+func (self *DiscordUnit) requiredIntents() (discordgo.Intent, []discordgo.Intent) {
+	self.handlerMutex.Lock()
+	defer self.handlerMutex.Unlock()
+
+	var result discordgo.Intent = discordgo.IntentsNone
+	var anyOf []discordgo.Intent
+
+	for eventType, count := range self.handlerEvents {
+		if count <= 0 {
+			continue
+		}
+
+		result |= eventIntents[eventType]
+
+		set, ok := eventAnyIntents[eventType]
+		if ok && !slices.Contains(anyOf, set) {
+			anyOf = append(anyOf, set)
+		}
+	}
+
+	return result, anyOf
+}
+
+// ComputeIntents returns the intents [DiscordUnit.Start] identifies with.
+//
+// With intent inference enabled, this is the configured intents combined with the [DiscordUnit.RequiredIntents],
+// leaving out privileged intents that were not opted into. Otherwise it is the configured intents.
+// A handler that can be served by any one of several intents, such as a message handler
+// with either GuildMessages or DirectMessages, is satisfied by any of them and infers nothing more.
+//
+// Returns the intents, and an error in strict mode when a required intent is missing.
+//
+// See: [DiscordUnitOptions]
+// See: [DiscordPrivilegedIntents]
+func (self *DiscordUnit) ComputeIntents() (discordgo.Intent, error) {
+	required, anyOf := self.requiredIntents()
+	intents := self.session.Identify.Intents
+
+	if self.inferIntents {
+		intents = self.baseIntents | (required &^ (DiscordPrivilegedIntents &^ self.privilegedIntents))
+
+		for _, set := range anyOf {
+			if intents & set == 0 {
+				intents |= preferredIntent(set)
+			}
+		}
+	}
+
+	var missing []string
+	if required &^ intents != 0 {
+		missing = append(missing, describeIntents(required &^ intents))
+	}
+
+	for _, set := range anyOf {
+		if intents & set == 0 {
+			missing = append(missing, "one of " + describeIntents(set))
+		}
+	}
+
+	if len(missing) == 0 {
+		return intents, nil
+	}
+
+	err := fmt.Errorf("%w: %s", ErrMissingIntents, strings.Join(missing, "; "))
+	if self.strictIntents {
+		return intents, err
+	}
+
+	self.logf("Warning: registered handlers will not fire: %v\n", err)
+	return intents, nil
+}
+
+// recordEvent counts a registered handler of an event type for intent inference. A negative delta removes it.
+func (self *DiscordUnit) recordEvent(eventType reflect.Type, delta int) {
+	self.handlerMutex.Lock()
+	defer self.handlerMutex.Unlock()
+
+	if self.handlerEvents == nil {
+		self.handlerEvents = make(map[reflect.Type]int)
+	}
+
+	self.handlerEvents[eventType] += delta
+}
+
+// preferredIntent returns the lowest intent of a set.
+func preferredIntent(set discordgo.Intent) discordgo.Intent {
+	return set & -set
+}
+
+// describeIntents lists the names of a set of intents.
+func describeIntents(intents discordgo.Intent) string {
+	names := make([]string, 0, 4)
+
+	for bit, name := range intentNames {
+		if intents & bit != 0 {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
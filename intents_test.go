@@ -0,0 +1,185 @@
+package ktncordgo
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var (
+	memberAddType = reflect.TypeOf(&discordgo.GuildMemberAdd{})
+	reactionAddType = reflect.TypeOf(&discordgo.MessageReactionAdd{})
+)
+
+// intentsUnit creates a unit identifying with the given intents, logging into a buffer.
+func intentsUnit(intents discordgo.Intent, events ...reflect.Type) (*DiscordUnit, *bytes.Buffer) {
+	session := &discordgo.Session{}
+	session.Identify.Intents = intents
+
+	var output bytes.Buffer
+	unit := &DiscordUnit{
+		session: session,
+		logger: log.New(&output, "", 0),
+		baseIntents: intents | discordgo.IntentsGuilds,
+	}
+
+	for _, eventType := range events {
+		unit.recordEvent(eventType, 1)
+	}
+
+	return unit, &output
+}
+
+func TestRequiredIntents(t *testing.T) {
+	tests := []struct {
+		name string
+		events []reflect.Type
+		expected discordgo.Intent
+	}{
+		{
+			name: "no handlers",
+			expected: discordgo.IntentsNone,
+		},
+		{
+			name: "messages prefer the guild intent",
+			events: []reflect.Type{messageCreateType, reflect.TypeOf(&discordgo.MessageUpdate{})},
+			expected: discordgo.IntentsGuildMessages,
+		},
+		{
+			name: "combined",
+			events: []reflect.Type{messageCreateType, memberAddType, reactionAddType},
+			expected: discordgo.IntentsGuildMessages | discordgo.IntentsGuildMembers | discordgo.IntentsGuildMessageReactions,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func (t *testing.T) {
+			unit, _ := intentsUnit(discordgo.IntentsNone, test.events...)
+
+			if required := unit.RequiredIntents(); required != test.expected {
+				t.Errorf("expected %s, got %s", describeIntents(test.expected), describeIntents(required))
+			}
+		})
+	}
+}
+
+func TestRequiredIntentsRemovedHandler(t *testing.T) {
+	unit, _ := intentsUnit(discordgo.IntentsNone, memberAddType)
+	unit.recordEvent(memberAddType, -1)
+
+	if required := unit.RequiredIntents(); required != discordgo.IntentsNone {
+		t.Errorf("expected no intents after removing the handler, got %s", describeIntents(required))
+	}
+}
+
+func TestComputeIntents(t *testing.T) {
+	tests := []struct {
+		name string
+		intents discordgo.Intent
+		events []reflect.Type
+		infer bool
+		strict bool
+		privileged discordgo.Intent
+		expected discordgo.Intent
+		err bool
+		warn bool
+	}{
+		{
+			name: "guild messages only",
+			intents: discordgo.IntentsGuilds | discordgo.IntentsGuildMessages,
+			events: []reflect.Type{messageCreateType},
+			strict: true,
+			expected: discordgo.IntentsGuilds | discordgo.IntentsGuildMessages,
+		},
+		{
+			name: "direct messages only",
+			intents: discordgo.IntentsDirectMessages,
+			events: []reflect.Type{messageCreateType},
+			strict: true,
+			expected: discordgo.IntentsDirectMessages,
+		},
+		{
+			name: "missing messages warns",
+			intents: discordgo.IntentsGuilds,
+			events: []reflect.Type{messageCreateType},
+			expected: discordgo.IntentsGuilds,
+			warn: true,
+		},
+		{
+			name: "missing messages fails in strict mode",
+			intents: discordgo.IntentsGuilds,
+			events: []reflect.Type{messageCreateType},
+			strict: true,
+			expected: discordgo.IntentsGuilds,
+			err: true,
+		},
+		{
+			name: "missing members fails in strict mode",
+			intents: discordgo.IntentsGuilds | discordgo.IntentsGuildMessages,
+			events: []reflect.Type{messageCreateType, memberAddType},
+			strict: true,
+			expected: discordgo.IntentsGuilds | discordgo.IntentsGuildMessages,
+			err: true,
+		},
+		{
+			name: "infer adds the guild message intent",
+			intents: discordgo.IntentsGuilds,
+			events: []reflect.Type{messageCreateType, reactionAddType},
+			infer: true,
+			strict: true,
+			expected: discordgo.IntentsGuilds | discordgo.IntentsGuildMessages | discordgo.IntentsGuildMessageReactions,
+		},
+		{
+			name: "infer keeps configured direct messages",
+			intents: discordgo.IntentsDirectMessages,
+			events: []reflect.Type{messageCreateType},
+			infer: true,
+			strict: true,
+			expected: discordgo.IntentsGuilds | discordgo.IntentsDirectMessages,
+		},
+		{
+			name: "infer skips privileged intents",
+			intents: discordgo.IntentsGuilds,
+			events: []reflect.Type{memberAddType},
+			infer: true,
+			expected: discordgo.IntentsGuilds,
+			warn: true,
+		},
+		{
+			name: "infer adds opted in privileged intents",
+			intents: discordgo.IntentsGuilds,
+			events: []reflect.Type{memberAddType},
+			infer: true,
+			strict: true,
+			privileged: discordgo.IntentsGuildMembers,
+			expected: discordgo.IntentsGuilds | discordgo.IntentsGuildMembers,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func (t *testing.T) {
+			unit, output := intentsUnit(test.intents, test.events...)
+			unit.inferIntents = test.infer
+			unit.strictIntents = test.strict
+			unit.privilegedIntents = test.privileged
+
+			intents, err := unit.ComputeIntents()
+
+			if intents != test.expected {
+				t.Errorf("expected %s, got %s", describeIntents(test.expected), describeIntents(intents))
+			}
+
+			if test.err != errors.Is(err, ErrMissingIntents) {
+				t.Errorf("expected error %t, got %v", test.err, err)
+			}
+
+			if test.warn != (output.Len() > 0) {
+				t.Errorf("expected warning %t, got %q", test.warn, output.String())
+			}
+		})
+	}
+}
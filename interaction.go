@@ -1,7 +1,9 @@
 package ktncordgo
 
 import (
-	"log"
+	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -9,22 +11,58 @@ import (
 // Discord returns the parent [DiscordUnit] object, the root of [ktncordgo].
 //
 // See: [DiscordUnit]
-func (self *DiscordInteractionUnit) Discord() IDiscordUnit {
+func (self *DiscordBaseInteractionUnit) Discord() IDiscordUnit {
 	return self.discord
 }
 
 // Native returns the underlying [discordgo.User] object.
 //
 // See: [discordgo.InteractionCreate]
-func (self *DiscordInteractionUnit) Native() *discordgo.InteractionCreate {
+func (self *DiscordBaseInteractionUnit) Native() *discordgo.InteractionCreate {
 	return self.interaction
 }
 
 // User returns the [DiscordUserUnit] instance of the command sender.
-func (self *DiscordInteractionUnit) User() IDiscordUserUnit {
+// In guilds the user is taken from the member, in direct messages from the interaction itself.
+//
+// See: [discordgo.Interaction.Member]
+// See: [discordgo.Interaction.User]
+func (self *DiscordBaseInteractionUnit) User() IDiscordUserUnit {
+	user := self.interaction.User
+	if self.interaction.Member != nil && self.interaction.Member.User != nil {
+		user = self.interaction.Member.User
+	}
+
+	if user == nil {
+		return nil
+	}
+
 	return &DiscordUserUnit{
 		discord: self.discord,
-		user: self.interaction.User,
+		user: user,
+	}
+}
+
+// Member returns the [DiscordMemberUnit] instance of the command sender,
+// including the permissions of the member in the channel of the interaction.
+//
+// Returns the member, or nil if the interaction did not happen in a guild.
+//
+// See: [discordgo.Interaction.Member]
+func (self *DiscordBaseInteractionUnit) Member() IDiscordMemberUnit {
+	if self.interaction.Member == nil {
+		return nil
+	}
+
+	// Copied, so the interaction payload shared with other handlers is not changed.
+	member := *self.interaction.Member
+	if member.GuildID == "" {
+		member.GuildID = self.interaction.GuildID
+	}
+
+	return &DiscordMemberUnit{
+		discord: self.discord,
+		member: &member,
 	}
 }
 
@@ -33,16 +71,33 @@ func (self *DiscordInteractionUnit) User() IDiscordUserUnit {
 // Typically discord requires a response within 3 seconds. Defer allows a delay of this.
 // This cannot be followed by a [Reply] call and instead requires a [EditReply] call to follow up.
 //
+// Parameters:
+//   flags - Optional flags of the reply, e.g. [discordgo.MessageFlagsEphemeral] to keep the reply private.
+//           The flags cannot be changed by the later [EditReply] call.
+//
 // Returns an error on failure.
 //
 // See: [discordgo.Session.InteractionRespond]
 // See: [discordgo.InteractionCreate.Interaction]
 // See: [discordgo.InteractionResponse]
 // See: [discordgo.InteractionResponseDeferredChannelMessageWithSource]
-func (self *DiscordInteractionUnit) DeferReply() error {
-	return self.discord.session.InteractionRespond(self.interaction.Interaction, &discordgo.InteractionResponse{
+func (self *DiscordBaseInteractionUnit) DeferReply(flags ...discordgo.MessageFlags) error {
+	var combined discordgo.MessageFlags
+	for _, flag := range flags {
+		combined |= flag
+	}
+
+	var data *discordgo.InteractionResponseData = nil
+	if combined != 0 {
+		data = &discordgo.InteractionResponseData{
+			Flags: combined,
+		}
+	}
+
+	return self.respond(&discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-	})
+		Data: data,
+	}, DiscordResponseDeferred)
 }
 
 // Reply sends a reply to user interaction. This cannot be used with [DeferReply].
@@ -57,19 +112,34 @@ func (self *DiscordInteractionUnit) DeferReply() error {
 // See: [discordgo.InteractionResponse]
 // See: [discordgo.InteractionResponseData]
 // See: [discordgo.InteractionResponseChannelMessageWithSource]
-func (self *DiscordInteractionUnit) Reply(message string) error {
-	return self.discord.session.InteractionRespond(self.interaction.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: message,
-		},
+func (self *DiscordBaseInteractionUnit) Reply(message string) error {
+	return self.ReplyOptions(DiscordMessageSend{
+		Content: message,
+	})
+}
+
+// ReplyEphemeral sends a reply to user interaction that only the user can see. This cannot be used with [DeferReply].
+//
+// Parameters:
+//   message - The text to include in the interaction response.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.MessageFlagsEphemeral]
+// See: [DiscordBaseInteractionUnit.ReplyOptions]
+func (self *DiscordBaseInteractionUnit) ReplyEphemeral(message string) error {
+	return self.ReplyOptions(DiscordMessageSend{
+		Content: message,
+		Flags: discordgo.MessageFlagsEphemeral,
 	})
 }
 
 // ReplyOptions sends a reply to user interaction with advanced options. This cannot be used with [DeferReply].
+// If the interaction was deferred by [DiscordUnit.SetAutoDefer], the deferred reply is edited instead.
+// A component deferred as a message update gets the reply as a follow-up, keeping its message unchanged.
 //
 // Parameters:
-//   opts - The message options including content, embeds, TTS, attachments, and allowed mentions.
+//   opts - The message options including content, embeds, TTS, attachments, allowed mentions, components, and flags.
 //
 // Returns an error on failure.
 //
@@ -79,30 +149,25 @@ func (self *DiscordInteractionUnit) Reply(message string) error {
 // See: [discordgo.InteractionResponse]
 // See: [discordgo.InteractionResponseData]
 // See: [discordgo.InteractionResponseChannelMessageWithSource]
-func (self *DiscordInteractionUnit) ReplyOptions(opts DiscordMessageSend) error {
-	embeds := convertAll(opts.Embeds, func (embed *DiscordEmbed) *discordgo.MessageEmbed {
-		return embed.Build()
-	})
-
-	files := convertAll(opts.Attachments, func (attachment *DiscordAttachment) *discordgo.File {
-		return attachment.Build()
-	})
-
-	var mentions *discordgo.MessageAllowedMentions = nil
-	if opts.AllowedMentions != nil {
-		mentions = opts.AllowedMentions.Build()
-	}
+func (self *DiscordBaseInteractionUnit) ReplyOptions(opts DiscordMessageSend) error {
+	return self.replyMessage(opts, discordgo.InteractionResponseChannelMessageWithSource)
+}
 
-	return self.discord.session.InteractionRespond(self.interaction.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: opts.Content,
-			Embeds: embeds,
-			TTS: opts.TTS,
-			Files: files,
-			AllowedMentions: mentions,
-		},
-	})
+// ShowModal responds to the interaction by opening a modal dialog. This cannot be used with [DeferReply] or [Reply].
+//
+// Parameters:
+//   modal - The modal to show.
+//
+// Returns an error on failure.
+//
+// See: [DiscordModal]
+// See: [discordgo.Session.InteractionRespond]
+// See: [discordgo.InteractionResponseModal]
+func (self *DiscordBaseInteractionUnit) ShowModal(modal DiscordModal) error {
+	return self.respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: modal.Build(),
+	}, DiscordResponseReplied)
 }
 
 // EditReply edits the interaction reply, or sends it if [DeferReply] was used.
@@ -115,18 +180,16 @@ func (self *DiscordInteractionUnit) ReplyOptions(opts DiscordMessageSend) error
 // See: [discordgo.Session.InteractionResponseEdit]
 // See: [discordgo.InteractionCreate.Interaction]
 // See: [discordgo.WebhookEdit]
-func (self *DiscordInteractionUnit) EditReply(message *string) error {
-	_, err := self.discord.session.InteractionResponseEdit(self.interaction.Interaction, &discordgo.WebhookEdit{
+func (self *DiscordBaseInteractionUnit) EditReply(message *string) error {
+	return self.EditReplyOptions(&DiscordMessageEdit{
 		Content: message,
 	})
-
-	return err
 }
 
 // EditReplyOptions edits the interaction reply with advanced options, or sends it if [DeferReply] was used.
 //
 // Parameters:
-//   opts - Reference to the message edit options including content, embeds, and allowed mentions.
+//   opts - Reference to the message edit options including content, embeds, allowed mentions, and components.
 //
 // Returns an error on failure.
 //
@@ -134,27 +197,114 @@ func (self *DiscordInteractionUnit) EditReply(message *string) error {
 // See: [discordgo.Session.InteractionResponseEdit]
 // See: [discordgo.InteractionCreate.Interaction]
 // See: [discordgo.WebhookEdit]
-func (self *DiscordInteractionUnit) EditReplyOptions(opts *DiscordMessageEdit) error {
-	var embeds *[]*discordgo.MessageEmbed = nil
-	if opts.Embeds != nil {
-		newEmbeds := convertAll(*opts.Embeds, func (embed *DiscordEmbed) *discordgo.MessageEmbed {
-			return embed.Build()
-		})
-		embeds = &newEmbeds
+func (self *DiscordBaseInteractionUnit) EditReplyOptions(opts *DiscordMessageEdit) error {
+	_, err := self.discord.session.InteractionResponseEdit(self.interaction.Interaction, opts.buildWebhookEdit())
+	if err != nil {
+		return self.responseError(err)
 	}
 
-	var mentions *discordgo.MessageAllowedMentions = nil
-	if opts.AllowedMentions != nil {
-		mentions = opts.AllowedMentions.Build()
+	self.markReplied()
+	return nil
+}
+
+// GetReply fetches the interaction reply, e.g. to read or react to it.
+//
+// Returns the reply message on success, otherwise an error.
+//
+// See: [DiscordMessageUnit]
+// See: [discordgo.Session.InteractionResponse]
+func (self *DiscordBaseInteractionUnit) GetReply() (IDiscordMessageUnit, error) {
+	msg, err := self.discord.session.InteractionResponse(self.interaction.Interaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch interaction reply: %w", self.responseError(err))
 	}
 
-	_, err := self.discord.session.InteractionResponseEdit(self.interaction.Interaction, &discordgo.WebhookEdit{
-		Content: opts.Content,
-		Embeds: embeds,
-		AllowedMentions: mentions,
+	return self.webhookMessage(msg), nil
+}
+
+// DeleteReply deletes the interaction reply.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.InteractionResponseDelete]
+func (self *DiscordBaseInteractionUnit) DeleteReply() error {
+	return self.responseError(self.discord.session.InteractionResponseDelete(self.interaction.Interaction))
+}
+
+// FollowUp sends an additional message after the interaction was replied to or deferred.
+//
+// Parameters:
+//   message - The text of the follow-up message.
+//
+// Returns the sent message on success, otherwise an error.
+//
+// See: [DiscordBaseInteractionUnit.FollowUpOptions]
+func (self *DiscordBaseInteractionUnit) FollowUp(message string) (IDiscordMessageUnit, error) {
+	return self.FollowUpOptions(DiscordMessageSend{
+		Content: message,
 	})
+}
+
+// FollowUpOptions sends an additional message with advanced options after the interaction was replied to or deferred.
+// Follow-ups can be sent for as long as the interaction token is valid, which is 15 minutes.
+//
+// Parameters:
+//   opts - The message options including content, embeds, TTS, attachments, allowed mentions, components, and flags.
+//
+// Returns the sent message on success, otherwise an error.
+//
+// See: [DiscordMessageSend]
+// See: [discordgo.Session.FollowupMessageCreate]
+func (self *DiscordBaseInteractionUnit) FollowUpOptions(opts DiscordMessageSend) (IDiscordMessageUnit, error) {
+	msg, err := self.discord.session.FollowupMessageCreate(self.interaction.Interaction, true, opts.buildWebhookParams())
+	if err != nil {
+		return nil, fmt.Errorf("failed to send follow-up message: %w", self.responseError(err))
+	}
+
+	return self.webhookMessage(msg), nil
+}
+
+// EditFollowUp edits a follow-up message sent with [DiscordBaseInteractionUnit.FollowUp].
+//
+// Parameters:
+//   messageId - The ID of the follow-up message.
+//   opts - Reference to the message edit options including content, embeds, allowed mentions, and components.
+//
+// Returns the edited message on success, otherwise an error.
+//
+// See: [DiscordMessageEdit]
+// See: [discordgo.Session.FollowupMessageEdit]
+func (self *DiscordBaseInteractionUnit) EditFollowUp(messageId string, opts *DiscordMessageEdit) (IDiscordMessageUnit, error) {
+	msg, err := self.discord.session.FollowupMessageEdit(self.interaction.Interaction, messageId, opts.buildWebhookEdit())
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit follow-up message: %w", self.responseError(err))
+	}
+
+	return self.webhookMessage(msg), nil
+}
 
-	return err
+// DeleteFollowUp deletes a follow-up message sent with [DiscordBaseInteractionUnit.FollowUp].
+//
+// Parameters:
+//   messageId - The ID of the follow-up message.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.FollowupMessageDelete]
+func (self *DiscordBaseInteractionUnit) DeleteFollowUp(messageId string) error {
+	return self.responseError(self.discord.session.FollowupMessageDelete(self.interaction.Interaction, messageId))
+}
+
+// webhookMessage wraps a message returned by the interaction webhook, which does not include the guild ID.
+func (self *DiscordBaseInteractionUnit) webhookMessage(msg *discordgo.Message) IDiscordMessageUnit {
+	if msg.GuildID == "" {
+		msg.GuildID = self.interaction.GuildID
+	}
+
+	return &DiscordMessageUnit{
+		discord: self.discord,
+		message: msg,
+	}
 }
 
 // CommandName returns the name/label of the slash command.
@@ -165,6 +315,34 @@ func (self *DiscordInteractionUnit) CommandName() string {
 	return self.interaction.ApplicationCommandData().Name
 }
 
+// CommandPath returns the full path of the slash command, including the subcommand group and subcommand.
+// E.g. "config roles add" for the command "config" with group "roles" and subcommand "add".
+//
+// See: [discordgo.InteractionCreate.ApplicationCommandData]
+// See: [discordgo.ApplicationCommandOptionSubCommandGroup]
+// See: [discordgo.ApplicationCommandOptionSubCommand]
+func (self *DiscordInteractionUnit) CommandPath() string {
+	return commandPath(self.interaction.ApplicationCommandData())
+}
+
+// commandPath joins the command name with the names of its subcommand group and subcommand.
+func commandPath(data discordgo.ApplicationCommandInteractionData) string {
+	path := []string{data.Name}
+	options := data.Options
+
+	for len(options) > 0 {
+		option := options[0]
+		if option.Type != discordgo.ApplicationCommandOptionSubCommandGroup && option.Type != discordgo.ApplicationCommandOptionSubCommand {
+			break
+		}
+
+		path = append(path, option.Name)
+		options = option.Options
+	}
+
+	return strings.Join(path, " ")
+}
+
 // IsCommandName returns true if the name/label of the slash command matches a provided value.
 //
 // Parameters:
@@ -179,6 +357,7 @@ func (self *DiscordInteractionUnit) IsCommandName(name string) bool {
 }
 
 // DispatchEvent matches the command name to a provided value, and if valid runs a provided callback.
+// An error returned by the callback is passed to the error sink of the [DiscordUnit].
 //
 // Parameters:
 //   name - The name of the command to test against.
@@ -195,8 +374,57 @@ func (self *DiscordInteractionUnit) DispatchEvent(name string, callback IDiscord
 
 	err := callback(self)
 	if err != nil {
-		log.Printf("Failed to run dispatched event '%s': %v\n", name, err)
+		self.discord.reportError(interactionEvent(self), err, nil, nil)
 	}
 
 	return true
 }
+
+// Bind fills a struct from the options of the slash command, including options nested in subcommands.
+//
+// Fields are matched by their `option` tag, e.g. `option:"target,required"`, and may carry a `default:"value"` tag.
+// Supported field types are strings, booleans, integers, floats, pointers to those (nil if missing),
+// [IDiscordUserUnit], [IDiscordChannelUnit], [IDiscordRoleUnit] and [discordgo.MessageAttachment] references.
+//
+// Parameters:
+//   dst - A pointer to the struct to fill.
+//
+// Returns a [DiscordOptionError] if an option is missing or invalid, or [ErrInvalidBindTarget] if dst cannot be bound.
+//
+// See: [DiscordOptionError]
+// See: [discordgo.ApplicationCommandInteractionData.Resolved]
+func (self *DiscordInteractionUnit) Bind(dst any) error {
+	if self.interaction.Type != discordgo.InteractionApplicationCommand && self.interaction.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		return fmt.Errorf("failed to bind options: interaction is of type %s", self.interaction.Type)
+	}
+
+	data := self.interaction.ApplicationCommandData()
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+
+	for _, option := range commandOptions(data.Options) {
+		options[option.Name] = option
+	}
+
+	return bindStruct(dst, func (field *optionField, target reflect.Value) (bool, error) {
+		option, ok := options[field.name]
+		if !ok {
+			return false, nil
+		}
+
+		return true, assignOption(self.discord, self.interaction.GuildID, field.name, target, option, data.Resolved)
+	})
+}
+
+// commandOptions returns the options of the innermost subcommand, or the given options if there are no subcommands.
+func commandOptions(options []*discordgo.ApplicationCommandInteractionDataOption) []*discordgo.ApplicationCommandInteractionDataOption {
+	for len(options) > 0 {
+		option := options[0]
+		if option.Type != discordgo.ApplicationCommandOptionSubCommandGroup && option.Type != discordgo.ApplicationCommandOptionSubCommand {
+			break
+		}
+
+		options = option.Options
+	}
+
+	return options
+}
@@ -1,6 +1,10 @@
 package ktncordgo
 
 import (
+	"context"
+	"iter"
+	"log"
+	"regexp"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -11,14 +15,45 @@ import (
 // See: [DiscordUnit]
 type IDiscordUnit interface {
 	Session() *discordgo.Session
+	Logger() *log.Logger
 	NewInteractionUnit(interaction *discordgo.InteractionCreate) IDiscordInteractionUnit
 
-	OnSlashCommand(func (IDiscordUnit, IDiscordInteractionUnit))
-	OnMessageCreate(func (IDiscordUnit, IDiscordMessageUnit))
+	OnSlashCommand(func (IDiscordUnit, IDiscordInteractionUnit)) IDiscordSubscription
+	OnMessageCreate(func (IDiscordUnit, IDiscordMessageUnit)) IDiscordSubscription
+	OnEvent(any) IDiscordSubscription
+	OnceSlashCommand(func (IDiscordUnit, IDiscordInteractionUnit)) IDiscordSubscription
+	OnceMessageCreate(func (IDiscordUnit, IDiscordMessageUnit)) IDiscordSubscription
+	OnceEvent(any) IDiscordSubscription
+	OnComponent(string, IDiscordComponentFn) IDiscordSubscription
+	OnComponentPrefix(string, IDiscordComponentFn) IDiscordSubscription
+	OnComponentMatch(*regexp.Regexp, IDiscordComponentFn) IDiscordSubscription
+	OnAutocomplete(IDiscordAutocompleteFn) IDiscordSubscription
+	OnUserCommand(string, IDiscordContextMenuFn) IDiscordSubscription
+	OnMessageCommand(string, IDiscordContextMenuFn) IDiscordSubscription
+	OnModalSubmit(string, IDiscordModalFn) IDiscordSubscription
+	OnModalSubmitPrefix(string, IDiscordModalFn) IDiscordSubscription
+
+	Use(...DiscordMiddleware)
+	SetErrorSink(func(*HandlerError))
+	SetErrorReply(string)
+	SetAutoDefer(time.Duration, discordgo.MessageFlags)
+
+	RequiredIntents() discordgo.Intent
+	ComputeIntents() (discordgo.Intent, error)
+
+	Router() *CommandRouter
+	SetRouter(*CommandRouter)
 
 	Start([]*discordgo.ApplicationCommand) error
 	Stop()
 
+	SyncCommands([]*discordgo.ApplicationCommand, DiscordCommandSyncOptions) (*DiscordCommandSyncReport, error)
+	SyncGuildCommands(string, []*discordgo.ApplicationCommand, DiscordCommandSyncOptions) (*DiscordCommandSyncReport, error)
+	SetDevGuild(string)
+	SetGuildCommands(string, []*discordgo.ApplicationCommand)
+	SetGuildCommandCleanup(bool)
+	RemoveGuildCommands(string) error
+
 	GetUser(string) (IDiscordUserUnit, error)
 	GetChannel(string) (IDiscordChannelUnit, error)
 	GetGuild(string) (IDiscordGuildUnit, error)
@@ -28,27 +63,153 @@ type IDiscordUnit interface {
 	BotId() string
 }
 
+// IDiscordEvent is the event interface passed through the handler middleware chain.
+//
+// See: [DiscordEvent]
+type IDiscordEvent interface {
+	Discord() IDiscordUnit
+	Native() any
+
+	Type() DiscordEventType
+	Name() string
+
+	Interaction() IDiscordBaseInteractionUnit
+	Message() IDiscordMessageUnit
+
+	GuildId() string
+	ChannelId() string
+	UserId() string
+}
+
+// IDiscordHandlerFn is an event handler function definition, as wrapped by [DiscordMiddleware].
+type IDiscordHandlerFn func(IDiscordEvent) error
+
+// DiscordMiddleware wraps an event handler with cross-cutting behavior.
+// A middleware can short-circuit the chain by returning without calling next.
+//
+// See: [DiscordUnit.Use]
+type DiscordMiddleware func(next IDiscordHandlerFn) IDiscordHandlerFn
+
+// IDiscordSubscription is the handle of a registered event handler.
+//
+// See: [DiscordSubscription]
+type IDiscordSubscription interface {
+	Remove()
+	Active() bool
+}
+
 // IDiscordCommandFn is a slash command handler callback function definition.
 type IDiscordCommandFn func(IDiscordInteractionUnit) error
 
-// IDiscordInteractionUnit is the slash command interaction interface.
+// IDiscordBaseInteractionUnit is the interface shared by every kind of interaction.
 //
-// See: [DiscordInteractionUnit]
-type IDiscordInteractionUnit interface {
+// See: [DiscordBaseInteractionUnit]
+type IDiscordBaseInteractionUnit interface {
 	Discord() IDiscordUnit
 	Native() *discordgo.InteractionCreate
 
 	User() IDiscordUserUnit
+	Member() IDiscordMemberUnit
 
-	DeferReply() error
+	ResponseState() DiscordResponseState
+	Respond(opts DiscordMessageSend) error
+	DeferReply(flags ...discordgo.MessageFlags) error
 	Reply(message string) error
+	ReplyEphemeral(message string) error
 	ReplyOptions(opts DiscordMessageSend) error
 	EditReply(message *string) error
 	EditReplyOptions(opts *DiscordMessageEdit) error
+	GetReply() (IDiscordMessageUnit, error)
+	DeleteReply() error
+
+	FollowUp(message string) (IDiscordMessageUnit, error)
+	FollowUpOptions(opts DiscordMessageSend) (IDiscordMessageUnit, error)
+	EditFollowUp(messageId string, opts *DiscordMessageEdit) (IDiscordMessageUnit, error)
+	DeleteFollowUp(messageId string) error
+}
+
+// IDiscordInteractionUnit is the slash command interaction interface.
+//
+// See: [DiscordInteractionUnit]
+type IDiscordInteractionUnit interface {
+	IDiscordBaseInteractionUnit
 
 	CommandName() string
+	CommandPath() string
 	IsCommandName(name string) bool
 	DispatchEvent(name string, callback IDiscordCommandFn) bool
+
+	Bind(dst any) error
+	ShowModal(modal DiscordModal) error
+}
+
+// IDiscordComponentFn is a message component handler callback function definition.
+type IDiscordComponentFn func(IDiscordComponentUnit) error
+
+// IDiscordComponentUnit is the message component interaction interface, used for buttons and select menus.
+//
+// See: [DiscordComponentUnit]
+type IDiscordComponentUnit interface {
+	IDiscordBaseInteractionUnit
+
+	CustomId() string
+	ComponentType() discordgo.ComponentType
+	Values() []string
+	Message() IDiscordMessageUnit
+
+	DeferUpdate() error
+	Update(opts DiscordMessageSend) error
+	ShowModal(modal DiscordModal) error
+}
+
+// IDiscordContextMenuFn is a context menu command handler callback function definition.
+type IDiscordContextMenuFn func(IDiscordContextMenuUnit) error
+
+// IDiscordContextMenuUnit is the user and message context menu command interface.
+//
+// See: [DiscordContextMenuUnit]
+type IDiscordContextMenuUnit interface {
+	IDiscordBaseInteractionUnit
+
+	CommandName() string
+	CommandType() discordgo.ApplicationCommandType
+	TargetId() string
+	TargetUser() IDiscordUserUnit
+	TargetMessage() IDiscordMessageUnit
+}
+
+// IDiscordAutocompleteFn is an autocomplete provider callback function definition.
+type IDiscordAutocompleteFn func(IDiscordAutocompleteUnit) error
+
+// IDiscordAutocompleteUnit is the autocomplete interaction interface.
+//
+// See: [DiscordAutocompleteUnit]
+type IDiscordAutocompleteUnit interface {
+	IDiscordBaseInteractionUnit
+
+	CommandName() string
+	CommandPath() string
+	FocusedOption() string
+	FocusedValue() string
+	OptionValue(name string) string
+
+	RespondChoices(choices ...DiscordChoice) error
+}
+
+// IDiscordModalFn is a modal submit handler callback function definition.
+type IDiscordModalFn func(IDiscordModalUnit) error
+
+// IDiscordModalUnit is the modal submit interaction interface.
+//
+// See: [DiscordModalUnit]
+type IDiscordModalUnit interface {
+	IDiscordBaseInteractionUnit
+
+	CustomId() string
+	Value(inputId string) string
+	Values() map[string]string
+
+	Bind(dst any) error
 }
 
 // IDiscordGuildUnit is the guild interface.
@@ -74,7 +235,26 @@ type IDiscordGuildUnit interface {
 	GetChannels() ([]IDiscordChannelUnit, error)
 	GetChannel(string) (IDiscordChannelUnit, error)
 
+	GetMember(userId string) (IDiscordMemberUnit, error)
 	GetMemberCount() (int, error)
+	Members(ctx context.Context) iter.Seq2[IDiscordMemberUnit, error]
+	SearchMembers(query string, limit int) ([]IDiscordMemberUnit, error)
+	ResolveMember(input string) (IDiscordMemberUnit, error)
+
+	GetRoles() ([]IDiscordRoleUnit, error)
+	GetRole(roleId string) (IDiscordRoleUnit, error)
+	CreateRole(params DiscordRoleParams) (IDiscordRoleUnit, error)
+	EditRole(roleId string, params DiscordRoleParams) (IDiscordRoleUnit, error)
+	DeleteRole(roleId string) error
+	ReorderRoles(positions map[string]int) ([]IDiscordRoleUnit, error)
+
+	Ban(userId string, reason string, deleteDays int) error
+	Unban(userId string, reason string) error
+	GetBans(after string, limit int) ([]*DiscordBan, error)
+	GetBan(userId string) (*DiscordBan, error)
+	Kick(userId string, reason string) error
+	Timeout(userId string, duration time.Duration, reason string) error
+	RemoveTimeout(userId string, reason string) error
 }
 
 // IDiscordChannelUnit is the channel interface.
@@ -103,6 +283,9 @@ type IDiscordChannelUnit interface {
 	FetchMessage(string) (IDiscordMessageUnit, error)
 	FetchMessages(limit int) ([]IDiscordMessageUnit, error)
 	GetLastMessage() (IDiscordMessageUnit, error)
+	GetMessagesSince(ctx context.Context, since time.Time) ([]IDiscordMessageUnit, error)
+	GetMessagesAround(messageId string, limit int) ([]IDiscordMessageUnit, error)
+	History(ctx context.Context, opts DiscordHistoryOptions) iter.Seq2[IDiscordMessageUnit, error]
 	SendMessage(string) (IDiscordMessageUnit, error)
 	SendMessageOptions(options DiscordMessageSend) (IDiscordMessageUnit, error)
 
@@ -125,6 +308,7 @@ type IDiscordMessageUnit interface {
 	Content() string
 
 	Author() IDiscordUserUnit
+	AuthorMember() IDiscordMemberUnit
 	Timestamp() time.Time
 	EditedTimestamp() *time.Time
 	Mentions() []IDiscordUserUnit
@@ -164,3 +348,66 @@ type IDiscordUserUnit interface {
 	IsNitroBasic() bool
 	IsNitro() bool
 }
+
+// IDiscordRoleUnit is the guild role interface.
+//
+// See: [DiscordRoleUnit]
+type IDiscordRoleUnit interface {
+	Discord() IDiscordUnit
+	Native() *discordgo.Role
+
+	// Base
+	Snowflake() string
+	Id() string
+	GuildId() string
+
+	// Information
+	Name() string
+	Color() int
+	Position() int
+	Permissions() int64
+	Mention() string
+
+	IsHoisted() bool
+	IsMentionable() bool
+	IsManaged() bool
+
+	// Methods
+	Members(ctx context.Context) ([]IDiscordMemberUnit, error)
+}
+
+// IDiscordMemberUnit is the guild member interface.
+//
+// See: [DiscordMemberUnit]
+type IDiscordMemberUnit interface {
+	Discord() IDiscordUnit
+	Native() *discordgo.Member
+
+	// Base
+	Snowflake() string
+	Id() string
+	GuildId() string
+	User() IDiscordUserUnit
+
+	// Information
+	Nickname() string
+	DisplayName() string
+	Roles() []string
+	HasRole(roleId string) bool
+	JoinedAt() time.Time
+
+	Permissions() int64
+	HasPermission(permission int64) bool
+
+	TimeoutUntil() *time.Time
+	IsTimedOut() bool
+	AvatarURL(size string) string
+
+	// Methods
+	AddRole(roleId string) error
+	RemoveRole(roleId string) error
+	SetNickname(nickname string) error
+	Timeout(duration time.Duration, reason string) error
+	Kick(reason string) error
+	Ban(reason string, deleteDays int) error
+}
@@ -0,0 +1,256 @@
+package ktncordgo
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord returns the parent [DiscordUnit] object, the root of [ktncordgo].
+//
+// See: [DiscordUnit]
+func (self *DiscordMemberUnit) Discord() IDiscordUnit {
+	return self.discord
+}
+
+// Native returns the underlying [discordgo.Member] object.
+//
+// See: [discordgo.Member]
+func (self *DiscordMemberUnit) Native() *discordgo.Member {
+	return self.member
+}
+
+// Snowflake returns the user ID of the guild member.
+//
+// See: [discordgo.User.ID]
+func (self *DiscordMemberUnit) Snowflake() string {
+	return self.member.User.ID
+}
+
+// Id returns the user ID of the guild member.
+//
+// See: [DiscordMemberUnit.Snowflake]
+func (self *DiscordMemberUnit) Id() string {
+	return self.member.User.ID
+}
+
+// GuildId returns the ID of the guild the member belongs to.
+//
+// See: [discordgo.Member.GuildID]
+func (self *DiscordMemberUnit) GuildId() string {
+	return self.member.GuildID
+}
+
+// User returns the [DiscordUserUnit] instance of the guild member.
+//
+// See: [discordgo.Member.User]
+func (self *DiscordMemberUnit) User() IDiscordUserUnit {
+	return &DiscordUserUnit{
+		discord: self.discord,
+		user: self.member.User,
+	}
+}
+
+// Nickname returns the guild nickname of the member, or an empty string if not set.
+//
+// See: [discordgo.Member.Nick]
+func (self *DiscordMemberUnit) Nickname() string {
+	return self.member.Nick
+}
+
+// DisplayName returns the name shown in the guild: the nickname, falling back to the global name and then the username.
+//
+// See: [discordgo.Member.DisplayName]
+func (self *DiscordMemberUnit) DisplayName() string {
+	return self.member.DisplayName()
+}
+
+// Roles returns the IDs of the roles of the guild member.
+//
+// See: [discordgo.Member.Roles]
+func (self *DiscordMemberUnit) Roles() []string {
+	return self.member.Roles
+}
+
+// HasRole returns true if the guild member has a role.
+//
+// Parameters:
+//   roleId - The ID of the role.
+func (self *DiscordMemberUnit) HasRole(roleId string) bool {
+	return slices.Contains(self.member.Roles, roleId)
+}
+
+// JoinedAt returns when the member joined the guild.
+//
+// See: [discordgo.Member.JoinedAt]
+func (self *DiscordMemberUnit) JoinedAt() time.Time {
+	return self.member.JoinedAt
+}
+
+// Permissions returns the permissions of the member in the channel of an interaction, including overwrites.
+// Only members taken from interactions carry permissions, otherwise this returns 0.
+//
+// See: [discordgo.Member.Permissions]
+func (self *DiscordMemberUnit) Permissions() int64 {
+	return self.member.Permissions
+}
+
+// HasPermission returns true if the member has a permission in the channel of an interaction.
+// Administrators have every permission.
+//
+// Parameters:
+//   permission - The permission bit, e.g. [discordgo.PermissionBanMembers].
+//
+// See: [DiscordMemberUnit.Permissions]
+func (self *DiscordMemberUnit) HasPermission(permission int64) bool {
+	if self.member.Permissions & discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+
+	return self.member.Permissions & permission == permission
+}
+
+// TimeoutUntil returns when the timeout of the member ends, or nil if the member was never timed out.
+// The time may be in the past if the timeout already ended.
+//
+// See: [discordgo.Member.CommunicationDisabledUntil]
+func (self *DiscordMemberUnit) TimeoutUntil() *time.Time {
+	return self.member.CommunicationDisabledUntil
+}
+
+// IsTimedOut returns true if the member is currently timed out.
+//
+// See: [DiscordMemberUnit.TimeoutUntil]
+func (self *DiscordMemberUnit) IsTimedOut() bool {
+	return self.member.CommunicationDisabledUntil != nil && self.member.CommunicationDisabledUntil.After(time.Now())
+}
+
+// AvatarURL returns the URL of the guild avatar of the member, falling back to the user avatar.
+//
+// Parameters:
+//   size - The size of the image as a power of two between 16 and 4096, or an empty string for the default.
+//
+// See: [discordgo.Member.AvatarURL]
+func (self *DiscordMemberUnit) AvatarURL(size string) string {
+	return self.member.AvatarURL(size)
+}
+
+// AddRole gives a role to the guild member.
+//
+// Parameters:
+//   roleId - The ID of the role.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.GuildMemberRoleAdd]
+func (self *DiscordMemberUnit) AddRole(roleId string) error {
+	err := self.discord.session.GuildMemberRoleAdd(self.member.GuildID, self.member.User.ID, roleId)
+	if err != nil {
+		return fmt.Errorf("failed to add role: %w", self.discord.moderationError(self.member.GuildID, discordgo.PermissionManageRoles, err))
+	}
+
+	if !self.HasRole(roleId) {
+		self.member.Roles = append(self.member.Roles, roleId)
+	}
+
+	return nil
+}
+
+// RemoveRole takes a role from the guild member.
+//
+// Parameters:
+//   roleId - The ID of the role.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.GuildMemberRoleRemove]
+func (self *DiscordMemberUnit) RemoveRole(roleId string) error {
+	err := self.discord.session.GuildMemberRoleRemove(self.member.GuildID, self.member.User.ID, roleId)
+	if err != nil {
+		return fmt.Errorf("failed to remove role: %w", self.discord.moderationError(self.member.GuildID, discordgo.PermissionManageRoles, err))
+	}
+
+	self.member.Roles = slices.DeleteFunc(self.member.Roles, func (id string) bool {
+		return id == roleId
+	})
+
+	return nil
+}
+
+// SetNickname changes the guild nickname of the member.
+//
+// Parameters:
+//   nickname - The new nickname, or an empty string to reset it.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.GuildMemberNickname]
+func (self *DiscordMemberUnit) SetNickname(nickname string) error {
+	userId := self.member.User.ID
+	if self.discord.session.State != nil && self.discord.session.State.User != nil && self.discord.session.State.User.ID == userId {
+		userId = "@me"
+	}
+
+	err := self.discord.session.GuildMemberNickname(self.member.GuildID, userId, nickname)
+	if err != nil {
+		return fmt.Errorf("failed to set nickname: %w", self.discord.moderationError(self.member.GuildID, discordgo.PermissionManageNicknames, err))
+	}
+
+	self.member.Nick = nickname
+	return nil
+}
+
+// Timeout prevents the member from chatting, reacting and joining voice channels for a duration.
+//
+// Parameters:
+//   duration - The length of the timeout, up to [DiscordMaxTimeout], or 0 to end an active timeout.
+//   reason - The reason shown in the audit log, may be empty.
+//
+// Returns an error on failure, or a [DiscordArgumentError] if duration is out of range.
+//
+// See: [DiscordGuildUnit.Timeout]
+func (self *DiscordMemberUnit) Timeout(duration time.Duration, reason string) error {
+	until, err := self.discord.timeoutMember(self.member.GuildID, self.member.User.ID, duration, reason)
+	if err != nil {
+		return err
+	}
+
+	self.member.CommunicationDisabledUntil = until
+	return nil
+}
+
+// Kick removes the member from the guild. The user can join again with an invite.
+//
+// Parameters:
+//   reason - The reason shown in the audit log, may be empty.
+//
+// Returns an error on failure.
+//
+// See: [DiscordGuildUnit.Kick]
+func (self *DiscordMemberUnit) Kick(reason string) error {
+	return self.discord.kickMember(self.member.GuildID, self.member.User.ID, reason)
+}
+
+// Ban removes the member from the guild and prevents the user from joining again.
+//
+// Parameters:
+//   reason - The reason shown in the audit log, may be empty.
+//   deleteDays - The number of days of messages of the user to delete, between 0 and [DiscordMaxBanDeleteDays].
+//
+// Returns an error on failure, or a [DiscordArgumentError] if deleteDays is out of range.
+//
+// See: [DiscordGuildUnit.Ban]
+func (self *DiscordMemberUnit) Ban(reason string, deleteDays int) error {
+	return self.discord.banUser(self.member.GuildID, self.member.User.ID, reason, deleteDays)
+}
+
+// auditLogReason returns the request option attaching a reason to the audit log entry, or none for an empty reason.
+func auditLogReason(reason string) []discordgo.RequestOption {
+	if reason == "" {
+		return nil
+	}
+
+	return []discordgo.RequestOption{discordgo.WithAuditLogReason(reason)}
+}
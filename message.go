@@ -2,7 +2,6 @@ package ktncordgo
 
 import (
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -46,7 +45,7 @@ func (self *DiscordMessageUnit) Id() string {
 func (self *DiscordMessageUnit) Channel() IDiscordChannelUnit {
 	channel, err := self.discord.session.Channel(self.message.ChannelID)
 	if err != nil {
-		log.Printf("Failed to fetch channel (id '%s') from message (id '%s'): %v\n", self.message.ChannelID, self.message.ID, err)
+		self.discord.logf("Failed to fetch channel (id '%s') from message (id '%s'): %v\n", self.message.ChannelID, self.message.ID, err)
 		return nil
 	}
 
@@ -78,6 +77,27 @@ func (self *DiscordMessageUnit) Author() IDiscordUserUnit {
 	}
 }
 
+// AuthorMember returns the sender of the message as a guild member.
+//
+// Returns the member, or nil for direct messages and messages not sent by guild members, like webhooks.
+//
+// See: [DiscordMemberUnit]
+// See: [discordgo.Message.Member]
+func (self *DiscordMessageUnit) AuthorMember() IDiscordMemberUnit {
+	if self.message.Member == nil || self.message.Author == nil {
+		return nil
+	}
+
+	member := *self.message.Member
+	member.User = self.message.Author
+	member.GuildID = self.message.GuildID
+
+	return &DiscordMemberUnit{
+		discord: self.discord,
+		member: &member,
+	}
+}
+
 // Timestamp returns the [time.Time] when the message was sent.
 //
 // See: [discordgo.Message.Timestamp]
@@ -0,0 +1,114 @@
+package ktncordgo
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CustomId returns the custom ID of the submitted modal.
+//
+// See: [discordgo.ModalSubmitInteractionData.CustomID]
+func (self *DiscordModalUnit) CustomId() string {
+	return self.interaction.ModalSubmitData().CustomID
+}
+
+// Value returns the submitted value of a text input.
+//
+// Parameters:
+//   inputId - The custom ID of the text input.
+//
+// Returns the value, or an empty string if the input was left empty or does not exist.
+func (self *DiscordModalUnit) Value(inputId string) string {
+	return self.Values()[inputId]
+}
+
+// Values returns the submitted values of every text input, keyed by their custom IDs.
+//
+// See: [discordgo.ModalSubmitInteractionData.Components]
+// See: [discordgo.TextInput]
+func (self *DiscordModalUnit) Values() map[string]string {
+	result := make(map[string]string)
+
+	for _, component := range self.interaction.ModalSubmitData().Components {
+		row, ok := component.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+
+		for _, inner := range row.Components {
+			if input, ok := inner.(*discordgo.TextInput); ok {
+				result[input.CustomID] = input.Value
+			}
+		}
+	}
+
+	return result
+}
+
+// Bind fills a struct from the submitted text inputs, matching the `option` tag of each field with a text input custom ID.
+// Empty inputs count as missing, so `default` and `required` apply the same way as with slash command options.
+//
+// Parameters:
+//   dst - A pointer to the struct to fill.
+//
+// Returns a [DiscordOptionError] if a value is missing or cannot be parsed, or [ErrInvalidBindTarget] if dst cannot be bound.
+//
+// See: [DiscordInteractionUnit.Bind]
+func (self *DiscordModalUnit) Bind(dst any) error {
+	values := self.Values()
+
+	return bindStruct(dst, func (field *optionField, target reflect.Value) (bool, error) {
+		value := values[field.name]
+		if value == "" {
+			return false, nil
+		}
+
+		return true, assignString(field.name, target, value)
+	})
+}
+
+// OnModalSubmit registers a handler for modal submissions with an exact custom ID.
+//
+// Parameters:
+//   customId - The custom ID of the modal.
+//   callback - The modal submit handler.
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordModalUnit]
+func (self *DiscordUnit) OnModalSubmit(customId string, callback IDiscordModalFn) IDiscordSubscription {
+	return self.addHandler(self.modalHandler(func (id string) bool {
+		return id == customId
+	}, callback), false)
+}
+
+// OnModalSubmitPrefix registers a handler for modal submissions whose custom ID starts with a prefix.
+//
+// Parameters:
+//   prefix - The custom ID prefix.
+//   callback - The modal submit handler.
+//
+// Returns the subscription handle, used to remove the handler.
+//
+// See: [IDiscordModalUnit]
+func (self *DiscordUnit) OnModalSubmitPrefix(prefix string, callback IDiscordModalFn) IDiscordSubscription {
+	return self.addHandler(self.modalHandler(func (id string) bool {
+		return strings.HasPrefix(id, prefix)
+	}, callback), false)
+}
+
+// modalHandler wraps a modal submit callback into an event listener.
+func (self *DiscordUnit) modalHandler(match func(string) bool, callback IDiscordModalFn) *discordListener {
+	return &discordListener{
+		eventType: interactionCreateType,
+		match: func (native any) bool {
+			interaction := native.(*discordgo.InteractionCreate)
+			return interaction.Type == discordgo.InteractionModalSubmit && match(interaction.ModalSubmitData().CustomID)
+		},
+		handle: func (event *DiscordEvent) error {
+			return callback(event.interaction.(*DiscordModalUnit))
+		},
+	}
+}
@@ -0,0 +1,255 @@
+package ktncordgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/ktnuity/ktnuitygo"
+)
+
+// DiscordMaxBanDeleteDays is the most days of messages discord deletes when banning a user.
+const DiscordMaxBanDeleteDays = 7
+
+// DiscordMaxTimeout is the longest timeout discord accepts.
+const DiscordMaxTimeout = 28 * 24 * time.Hour
+
+// Ban bans a user from the discord guild, preventing them from joining again. The user does not need to be a member.
+//
+// Parameters:
+//   userId - The ID of the user.
+//   reason - The reason shown in the audit log, may be empty.
+//   deleteDays - The number of days of messages of the user to delete, between 0 and 7.
+//
+// Returns an error on failure, a [DiscordArgumentError] if deleteDays is out of range,
+// or wrapping [ErrMissingPermissions] or [ErrRoleHierarchy] if discord refused the action.
+//
+// See: [discordgo.Session.GuildBanCreate]
+func (self *DiscordGuildUnit) Ban(userId string, reason string, deleteDays int) error {
+	return self.discord.banUser(self.guild.ID, userId, reason, deleteDays)
+}
+
+// Unban lifts the ban of a user from the discord guild.
+//
+// Parameters:
+//   userId - The ID of the user.
+//   reason - The reason shown in the audit log, may be empty.
+//
+// Returns an error on failure, wrapping [ErrMissingPermissions] if discord refused the action.
+//
+// See: [discordgo.Session.GuildBanDelete]
+func (self *DiscordGuildUnit) Unban(userId string, reason string) error {
+	err := self.discord.session.GuildBanDelete(self.guild.ID, userId, auditLogReason(reason)...)
+	if err != nil {
+		return fmt.Errorf("failed to unban user: %w", self.discord.moderationError(self.guild.ID, discordgo.PermissionBanMembers, err))
+	}
+
+	return nil
+}
+
+// GetBans returns a page of the bans of the discord guild, ordered by user ID.
+// To fetch the next page, pass the user ID of the last ban as after.
+//
+// Parameters:
+//   after - The user ID to start after, or an empty string to start at the beginning.
+//   limit - The maximum number of bans, up to 1000. 0 uses discord's default of 1000.
+//
+// Returns the bans on success, otherwise an error.
+//
+// See: [DiscordBan]
+// See: [discordgo.Session.GuildBans]
+func (self *DiscordGuildUnit) GetBans(after string, limit int) ([]*DiscordBan, error) {
+	bans, err := self.discord.session.GuildBans(self.guild.ID, limit, "", after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bans: %w", self.discord.moderationError(self.guild.ID, discordgo.PermissionBanMembers, err))
+	}
+
+	return convertAll(bans, self.wrapBan), nil
+}
+
+// GetBan returns the ban of a user in the discord guild.
+//
+// Parameters:
+//   userId - The ID of the user.
+//
+// Returns the ban if the user is banned, otherwise an error.
+//
+// See: [DiscordBan]
+// See: [discordgo.Session.GuildBan]
+func (self *DiscordGuildUnit) GetBan(userId string) (*DiscordBan, error) {
+	ban, err := self.discord.session.GuildBan(self.guild.ID, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ban: %w", self.discord.moderationError(self.guild.ID, discordgo.PermissionBanMembers, err))
+	}
+
+	return self.wrapBan(ban), nil
+}
+
+// Kick removes a member from the discord guild. The user can join again with an invite.
+//
+// Parameters:
+//   userId - The ID of the member.
+//   reason - The reason shown in the audit log, may be empty.
+//
+// Returns an error on failure, wrapping [ErrMissingPermissions] or [ErrRoleHierarchy] if discord refused the action.
+//
+// See: [discordgo.Session.GuildMemberDelete]
+func (self *DiscordGuildUnit) Kick(userId string, reason string) error {
+	return self.discord.kickMember(self.guild.ID, userId, reason)
+}
+
+// Timeout prevents a member of the discord guild from chatting, reacting and joining voice channels for a duration.
+//
+// Parameters:
+//   userId - The ID of the member.
+//   duration - The length of the timeout, up to [DiscordMaxTimeout].
+//   reason - The reason shown in the audit log, may be empty.
+//
+// Returns an error on failure, a [DiscordArgumentError] if duration is out of range,
+// or wrapping [ErrMissingPermissions] or [ErrRoleHierarchy] if discord refused the action.
+//
+// See: [discordgo.Session.GuildMemberTimeout]
+func (self *DiscordGuildUnit) Timeout(userId string, duration time.Duration, reason string) error {
+	_, err := self.discord.timeoutMember(self.guild.ID, userId, duration, reason)
+	return err
+}
+
+// RemoveTimeout ends the active timeout of a member of the discord guild.
+//
+// Parameters:
+//   userId - The ID of the member.
+//   reason - The reason shown in the audit log, may be empty.
+//
+// Returns an error on failure, wrapping [ErrMissingPermissions] or [ErrRoleHierarchy] if discord refused the action.
+//
+// See: [discordgo.Session.GuildMemberTimeout]
+func (self *DiscordGuildUnit) RemoveTimeout(userId string, reason string) error {
+	_, err := self.discord.timeoutMember(self.guild.ID, userId, 0, reason)
+	return err
+}
+
+// wrapBan wraps a ban entry of the guild.
+func (self *DiscordGuildUnit) wrapBan(ban *discordgo.GuildBan) *DiscordBan {
+	return &DiscordBan{
+		User: &DiscordUserUnit{
+			discord: self.discord,
+			user: ban.User,
+		},
+		Reason: ban.Reason,
+	}
+}
+
+// banUser bans a user from a guild, shared by guild and member units.
+func (self *DiscordUnit) banUser(guildId string, userId string, reason string, deleteDays int) error {
+	if deleteDays < 0 || deleteDays > DiscordMaxBanDeleteDays {
+		return &DiscordArgumentError{
+			Argument: "deleteDays",
+			Value: deleteDays,
+			Reason: fmt.Sprintf("must be between 0 and %d", DiscordMaxBanDeleteDays),
+		}
+	}
+
+	err := self.session.GuildBanCreate(guildId, userId, deleteDays, auditLogReason(reason)...)
+	if err != nil {
+		return fmt.Errorf("failed to ban user: %w", self.moderationError(guildId, discordgo.PermissionBanMembers, err))
+	}
+
+	return nil
+}
+
+// kickMember removes a member from a guild, shared by guild and member units.
+func (self *DiscordUnit) kickMember(guildId string, userId string, reason string) error {
+	err := self.session.GuildMemberDelete(guildId, userId, auditLogReason(reason)...)
+	if err != nil {
+		return fmt.Errorf("failed to kick member: %w", self.moderationError(guildId, discordgo.PermissionKickMembers, err))
+	}
+
+	return nil
+}
+
+// timeoutMember times out a member of a guild, or ends the timeout for a zero duration, shared by guild and member units.
+// Returns the end of the timeout, or nil if it was ended.
+func (self *DiscordUnit) timeoutMember(guildId string, userId string, duration time.Duration, reason string) (*time.Time, error) {
+	if duration < 0 || duration > DiscordMaxTimeout {
+		return nil, &DiscordArgumentError{
+			Argument: "duration",
+			Value: duration,
+			Reason: fmt.Sprintf("must be between 0 and %s", DiscordMaxTimeout),
+		}
+	}
+
+	var until *time.Time = nil
+	if duration > 0 {
+		until = ktnuitygo.AsRef(time.Now().Add(duration))
+	}
+
+	err := self.session.GuildMemberTimeout(guildId, userId, until, auditLogReason(reason)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to timeout member: %w", self.moderationError(guildId, discordgo.PermissionModerateMembers, err))
+	}
+
+	return until, nil
+}
+
+// moderationError tells apart missing permissions from role hierarchy violations, which discord reports with the same code.
+// Discord gives no further detail, so this is a best guess: if the bot has the permission the action needs,
+// the target is assumed to be above the bot, otherwise, or if the permissions cannot be computed, the permission is assumed missing.
+// Other refusals reported with the same code, like timing out an administrator, are classified the same way.
+func (self *DiscordUnit) moderationError(guildId string, permission int64, err error) error {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Message == nil || restErr.Message.Code != discordgo.ErrCodeMissingPermissions {
+		return err
+	}
+
+	permissions, permErr := self.botPermissions(guildId)
+	if permErr == nil && permissions & permission == permission {
+		return fmt.Errorf("%w: %w", ErrRoleHierarchy, err)
+	}
+
+	return fmt.Errorf("%w: %w", ErrMissingPermissions, err)
+}
+
+// botPermissions computes the guild-wide permissions of the bot from its roles, using the state when available.
+func (self *DiscordUnit) botPermissions(guildId string) (int64, error) {
+	botId, err := self.applicationId()
+	if err != nil {
+		return 0, err
+	}
+
+	member, err := self.session.State.Member(guildId, botId)
+	if err != nil {
+		member, err = self.session.GuildMember(guildId, botId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var roles []*discordgo.Role
+	if guild, err := self.session.State.Guild(guildId); err == nil {
+		if guild.OwnerID == botId {
+			return discordgo.PermissionAll, nil
+		}
+
+		roles = guild.Roles
+	} else {
+		roles, err = self.session.GuildRoles(guildId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var permissions int64 = 0
+	for _, role := range roles {
+		if role.ID == guildId || slices.Contains(member.Roles, role.ID) {
+			permissions |= role.Permissions
+		}
+	}
+
+	if permissions & discordgo.PermissionAdministrator != 0 {
+		return discordgo.PermissionAll, nil
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,274 @@
+package ktncordgo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// baseInteraction is implemented by every interaction unit, giving access to the shared response state.
+type baseInteraction interface {
+	base() *DiscordBaseInteractionUnit
+}
+
+// base returns the interaction unit itself, promoted to every unit embedding it.
+func (self *DiscordBaseInteractionUnit) base() *DiscordBaseInteractionUnit {
+	return self
+}
+
+// ResponseState returns whether the interaction was not responded to yet, deferred, or replied to.
+//
+// See: [DiscordResponseState]
+func (self *DiscordBaseInteractionUnit) ResponseState() DiscordResponseState {
+	self.stateMutex.Lock()
+	defer self.stateMutex.Unlock()
+
+	return self.state
+}
+
+// Respond sends a message in response to the interaction, picking the right call for the response state:
+// the initial reply if nothing was sent yet, an edit of the deferred reply after [DeferReply],
+// and a follow-up message once replied. After a component was deferred as a message update,
+// the message is sent as a follow-up, leaving the message of the component unchanged.
+//
+// Parameters:
+//   opts - The message options. Flags cannot be changed when editing a deferred reply.
+//
+// Returns an error on failure, wrapping [ErrInteractionExpired] if the interaction token is no longer valid,
+// or [ErrDeferredFlags] if the message needs flags the deferred reply does not have.
+//
+// See: [DiscordBaseInteractionUnit.ResponseState]
+func (self *DiscordBaseInteractionUnit) Respond(opts DiscordMessageSend) error {
+	if self.ResponseState() == DiscordResponseNone {
+		err := self.ReplyOptions(opts)
+
+		// Unless the interaction was deferred in the meantime, e.g. by the automatic defer.
+		if !errors.Is(err, ErrAlreadyResponded) || self.ResponseState() == DiscordResponseNone {
+			return err
+		}
+	}
+
+	if self.ResponseState() == DiscordResponseDeferred {
+		self.responseMutex.Lock()
+		defer self.responseMutex.Unlock()
+
+		if self.isDeferredUpdate() {
+			return self.sendFollowUp(opts)
+		}
+
+		return self.sendDeferred(opts)
+	}
+
+	_, err := self.FollowUpOptions(opts)
+	return err
+}
+
+// SetAutoDefer makes interaction handlers defer their reply automatically when they have not responded
+// after a threshold, so slow handlers do not miss the 3 second window.
+// Replies sent after the automatic defer edit the deferred reply instead, failing with [ErrDeferredFlags]
+// if they need flags the defer did not set.
+// Component interactions are deferred as message updates. Replies sent after that are follow-ups,
+// while [DiscordComponentUnit.Update] edits the message. Autocomplete interactions are never deferred.
+//
+// Parameters:
+//   threshold - How long to wait for a response before deferring, or 0 to disable.
+//   flags - The flags of the deferred reply, e.g. [discordgo.MessageFlagsEphemeral].
+//
+// See: [DiscordBaseInteractionUnit.DeferReply]
+func (self *DiscordUnit) SetAutoDefer(threshold time.Duration, flags discordgo.MessageFlags) {
+	self.middlewareMutex.Lock()
+	defer self.middlewareMutex.Unlock()
+
+	self.autoDefer = threshold
+	self.autoDeferFlags = flags
+}
+
+// startAutoDefer schedules the automatic defer of an interaction event, returning a function cancelling it.
+func (self *DiscordUnit) startAutoDefer(event *DiscordEvent) func() {
+	self.middlewareMutex.RLock()
+	threshold := self.autoDefer
+	flags := self.autoDeferFlags
+	self.middlewareMutex.RUnlock()
+
+	if threshold <= 0 || event.interaction == nil {
+		return func () {}
+	}
+
+	unit, ok := event.interaction.(baseInteraction)
+	if !ok || unit.base().interaction.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		return func () {}
+	}
+
+	timer := time.AfterFunc(threshold, func () {
+		err := unit.base().autoDefer(flags)
+		if err != nil && !errors.Is(err, ErrAlreadyResponded) {
+			self.logf("Failed to defer interaction automatically: %v\n", err)
+		}
+	})
+
+	return func () {
+		timer.Stop()
+	}
+}
+
+// autoDefer defers the interaction if it was not responded to yet.
+func (self *DiscordBaseInteractionUnit) autoDefer(flags discordgo.MessageFlags) error {
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}
+
+	if self.interaction.Type == discordgo.InteractionMessageComponent {
+		response.Type = discordgo.InteractionResponseDeferredMessageUpdate
+	} else if flags != 0 {
+		response.Data = &discordgo.InteractionResponseData{
+			Flags: flags,
+		}
+	}
+
+	self.responseMutex.Lock()
+	defer self.responseMutex.Unlock()
+
+	err := self.respondLocked(response, DiscordResponseDeferred)
+	if err == nil {
+		self.stateMutex.Lock()
+		self.autoDeferred = true
+		self.stateMutex.Unlock()
+	}
+
+	return err
+}
+
+// respond sends the initial response of the interaction, moving it to the given state.
+func (self *DiscordBaseInteractionUnit) respond(response *discordgo.InteractionResponse, state DiscordResponseState) error {
+	self.responseMutex.Lock()
+	defer self.responseMutex.Unlock()
+
+	return self.respondLocked(response, state)
+}
+
+// respondLocked sends the initial response of the interaction. The caller must hold the response lock,
+// which serializes responses, while the state lock is only held to read and update the state.
+func (self *DiscordBaseInteractionUnit) respondLocked(response *discordgo.InteractionResponse, state DiscordResponseState) error {
+	if self.ResponseState() != DiscordResponseNone {
+		return ErrAlreadyResponded
+	}
+
+	err := self.discord.session.InteractionRespond(self.interaction.Interaction, response)
+	if err != nil {
+		return self.responseError(err)
+	}
+
+	self.stateMutex.Lock()
+	defer self.stateMutex.Unlock()
+
+	self.state = state
+	self.deferredUpdate = response.Type == discordgo.InteractionResponseDeferredMessageUpdate
+	if state == DiscordResponseDeferred && response.Data != nil {
+		self.deferFlags = response.Data.Flags
+	}
+
+	return nil
+}
+
+// replyMessage sends a message as the initial response, or completes the automatic defer of the interaction.
+// After a deferred message update, only an update edits the message, while replies are sent as follow-ups.
+func (self *DiscordBaseInteractionUnit) replyMessage(opts DiscordMessageSend, responseType discordgo.InteractionResponseType) error {
+	self.responseMutex.Lock()
+	defer self.responseMutex.Unlock()
+
+	self.stateMutex.Lock()
+	autoDeferred := self.autoDeferred && self.state == DiscordResponseDeferred
+	deferredUpdate := self.deferredUpdate
+	self.stateMutex.Unlock()
+
+	if autoDeferred && deferredUpdate && responseType != discordgo.InteractionResponseUpdateMessage {
+		return self.sendFollowUp(opts)
+	}
+
+	if autoDeferred {
+		return self.sendDeferred(opts)
+	}
+
+	message := opts.Build()
+
+	return self.respondLocked(&discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{
+			Content: message.Content,
+			Embeds: message.Embeds,
+			TTS: message.TTS,
+			Files: message.Files,
+			AllowedMentions: message.AllowedMentions,
+			Components: message.Components,
+			Flags: message.Flags,
+		},
+	}, DiscordResponseReplied)
+}
+
+// sendDeferred sends a message by editing the deferred reply, including its files. The caller must hold the response lock.
+// Flags that the deferral did not set cannot be added by the edit, so they are rejected rather than dropped.
+func (self *DiscordBaseInteractionUnit) sendDeferred(opts DiscordMessageSend) error {
+	self.stateMutex.Lock()
+	flags := self.deferFlags
+	self.stateMutex.Unlock()
+
+	if opts.Flags &^ flags != 0 {
+		return fmt.Errorf("%w: deferred with flags %d, message needs %d", ErrDeferredFlags, flags, opts.Flags)
+	}
+
+	_, err := self.discord.session.InteractionResponseEdit(self.interaction.Interaction, opts.buildWebhookEdit())
+	if err != nil {
+		return self.responseError(err)
+	}
+
+	self.markReplied()
+	return nil
+}
+
+// sendFollowUp sends a message as a follow-up of a deferred message update. The caller must hold the response lock.
+func (self *DiscordBaseInteractionUnit) sendFollowUp(opts DiscordMessageSend) error {
+	_, err := self.FollowUpOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	self.markReplied()
+	return nil
+}
+
+// isDeferredUpdate returns true if the interaction was deferred as a message update.
+func (self *DiscordBaseInteractionUnit) isDeferredUpdate() bool {
+	self.stateMutex.Lock()
+	defer self.stateMutex.Unlock()
+
+	return self.deferredUpdate
+}
+
+// markReplied records that a deferred reply was sent by editing it.
+func (self *DiscordBaseInteractionUnit) markReplied() {
+	self.stateMutex.Lock()
+	defer self.stateMutex.Unlock()
+
+	if self.state == DiscordResponseDeferred {
+		self.state = DiscordResponseReplied
+	}
+}
+
+// responseError wraps errors caused by an invalid interaction token with [ErrInteractionExpired].
+func (self *DiscordBaseInteractionUnit) responseError(err error) error {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Message == nil {
+		return err
+	}
+
+	switch restErr.Message.Code {
+	case discordgo.ErrCodeUnknownInteraction, discordgo.ErrCodeUnknownWebhook:
+		return fmt.Errorf("%w: %w", ErrInteractionExpired, err)
+	case discordgo.ErrCodeInteractionHasAlreadyBeenAcknowledged:
+		return fmt.Errorf("%w: %w", ErrAlreadyResponded, err)
+	}
+
+	return err
+}
@@ -0,0 +1,260 @@
+package ktncordgo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord returns the parent [DiscordUnit] object, the root of [ktncordgo].
+//
+// See: [DiscordUnit]
+func (self *DiscordRoleUnit) Discord() IDiscordUnit {
+	return self.discord
+}
+
+// Native returns the underlying [discordgo.Role] object.
+//
+// See: [discordgo.Role]
+func (self *DiscordRoleUnit) Native() *discordgo.Role {
+	return self.role
+}
+
+// Snowflake returns the ID of the discord role.
+//
+// See: [discordgo.Role.ID]
+func (self *DiscordRoleUnit) Snowflake() string {
+	return self.role.ID
+}
+
+// Id returns the ID of the discord role.
+//
+// See: [DiscordRoleUnit.Snowflake]
+func (self *DiscordRoleUnit) Id() string {
+	return self.role.ID
+}
+
+// GuildId returns the ID of the guild the role belongs to.
+func (self *DiscordRoleUnit) GuildId() string {
+	return self.guildId
+}
+
+// Name returns the name of the discord role.
+//
+// See: [discordgo.Role.Name]
+func (self *DiscordRoleUnit) Name() string {
+	return self.role.Name
+}
+
+// Color returns the color of the discord role as an RGB integer, or 0 if the role has no color.
+//
+// See: [discordgo.Role.Color]
+func (self *DiscordRoleUnit) Color() int {
+	return self.role.Color
+}
+
+// Position returns the position of the discord role in the role list, where higher roles have higher positions.
+//
+// See: [discordgo.Role.Position]
+func (self *DiscordRoleUnit) Position() int {
+	return self.role.Position
+}
+
+// Permissions returns the permission bits granted by the discord role.
+//
+// See: [discordgo.Role.Permissions]
+func (self *DiscordRoleUnit) Permissions() int64 {
+	return self.role.Permissions
+}
+
+// Mention returns the string used to mention the discord role in a message.
+//
+// See: [discordgo.Role.Mention]
+func (self *DiscordRoleUnit) Mention() string {
+	return self.role.Mention()
+}
+
+// IsHoisted returns true if members of the discord role are shown separately in the member list.
+//
+// See: [discordgo.Role.Hoist]
+func (self *DiscordRoleUnit) IsHoisted() bool {
+	return self.role.Hoist
+}
+
+// IsMentionable returns true if anyone can mention the discord role.
+//
+// See: [discordgo.Role.Mentionable]
+func (self *DiscordRoleUnit) IsMentionable() bool {
+	return self.role.Mentionable
+}
+
+// IsManaged returns true if the discord role is managed by an integration, like a bot role.
+//
+// See: [discordgo.Role.Managed]
+func (self *DiscordRoleUnit) IsManaged() bool {
+	return self.role.Managed
+}
+
+// Members returns the members that have the discord role.
+// Note: This scans every member of the guild, be wary of usage in larger servers.
+//
+// Parameters:
+//   ctx - The context cancelling the scan.
+//
+// Returns the members on success, otherwise an error.
+//
+// See: [DiscordMemberUnit]
+// See: [DiscordGuildUnit.Members]
+func (self *DiscordRoleUnit) Members(ctx context.Context) ([]IDiscordMemberUnit, error) {
+	result := make([]IDiscordMemberUnit, 0)
+
+	for member, err := range guildMembers(ctx, self.discord, self.guildId) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch role members: %w", err)
+		}
+
+		if self.role.ID == self.guildId || member.HasRole(self.role.ID) {
+			result = append(result, member)
+		}
+	}
+
+	return result, nil
+}
+
+// GetRoles returns the roles of the discord guild.
+//
+// Returns the roles on success, otherwise an error.
+//
+// See: [DiscordRoleUnit]
+// See: [discordgo.Session.GuildRoles]
+func (self *DiscordGuildUnit) GetRoles() ([]IDiscordRoleUnit, error) {
+	roles, err := self.discord.session.GuildRoles(self.guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+	}
+
+	return self.wrapRoles(roles), nil
+}
+
+// GetRole returns a role of the discord guild.
+//
+// Parameters:
+//   roleId - The ID of the role.
+//
+// Returns the role if found, otherwise an error.
+//
+// See: [DiscordRoleUnit]
+// See: [DiscordGuildUnit.GetRoles]
+func (self *DiscordGuildUnit) GetRole(roleId string) (IDiscordRoleUnit, error) {
+	roles, err := self.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if role.Id() == roleId {
+			return role, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to fetch role: role not found")
+}
+
+// CreateRole creates a new role in the discord guild.
+//
+// Parameters:
+//   params - The settings of the role.
+//
+// Returns the created role on success, otherwise an error.
+//
+// See: [DiscordRoleParams]
+// See: [discordgo.Session.GuildRoleCreate]
+func (self *DiscordGuildUnit) CreateRole(params DiscordRoleParams) (IDiscordRoleUnit, error) {
+	role, err := self.discord.session.GuildRoleCreate(self.guild.ID, params.Build())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return self.wrapRole(role), nil
+}
+
+// EditRole changes the settings of a role in the discord guild.
+//
+// Parameters:
+//   roleId - The ID of the role.
+//   params - The settings to change.
+//
+// Returns the edited role on success, otherwise an error.
+//
+// See: [DiscordRoleParams]
+// See: [discordgo.Session.GuildRoleEdit]
+func (self *DiscordGuildUnit) EditRole(roleId string, params DiscordRoleParams) (IDiscordRoleUnit, error) {
+	role, err := self.discord.session.GuildRoleEdit(self.guild.ID, roleId, params.Build())
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit role: %w", err)
+	}
+
+	return self.wrapRole(role), nil
+}
+
+// DeleteRole deletes a role from the discord guild.
+//
+// Parameters:
+//   roleId - The ID of the role.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.GuildRoleDelete]
+func (self *DiscordGuildUnit) DeleteRole(roleId string) error {
+	err := self.discord.session.GuildRoleDelete(self.guild.ID, roleId)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderRoles moves roles of the discord guild to new positions. Roles not included keep their position.
+//
+// Parameters:
+//   positions - The new positions, keyed by role ID.
+//
+// Returns every role of the guild after reordering on success, otherwise an error.
+//
+// See: [discordgo.Session.GuildRoleReorder]
+func (self *DiscordGuildUnit) ReorderRoles(positions map[string]int) ([]IDiscordRoleUnit, error) {
+	order := make([]*discordgo.Role, 0, len(positions))
+	for roleId, position := range positions {
+		order = append(order, &discordgo.Role{
+			ID: roleId,
+			Position: position,
+		})
+	}
+
+	roles, err := self.discord.session.GuildRoleReorder(self.guild.ID, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder roles: %w", err)
+	}
+
+	return self.wrapRoles(roles), nil
+}
+
+// wrapRole wraps a role of the guild.
+func (self *DiscordGuildUnit) wrapRole(role *discordgo.Role) IDiscordRoleUnit {
+	return &DiscordRoleUnit{
+		discord: self.discord,
+		guildId: self.guild.ID,
+		role: role,
+	}
+}
+
+// wrapRoles wraps the roles of the guild, sorted by position from the top.
+func (self *DiscordGuildUnit) wrapRoles(roles []*discordgo.Role) []IDiscordRoleUnit {
+	slices.SortStableFunc(roles, func (a, b *discordgo.Role) int {
+		return b.Position - a.Position
+	})
+
+	return convertAll(roles, self.wrapRole)
+}
@@ -0,0 +1,382 @@
+package ktncordgo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// NewCommandRouter creates an empty [CommandRouter].
+//
+// Returns the created [CommandRouter] reference.
+//
+// See: [CommandRouter.Handle]
+// See: [DiscordUnit.SetRouter]
+func NewCommandRouter() *CommandRouter {
+	return &CommandRouter{
+		routes: make(map[string]IDiscordCommandFn),
+		specs: make(map[string]*commandSpec),
+		autocomplete: make(map[string]map[string]IDiscordAutocompleteFn),
+		contextRoutes: make(map[discordgo.ApplicationCommandType]map[string]IDiscordContextMenuFn),
+	}
+}
+
+// Handle registers a command handler for a command path.
+//
+// A path is the command name, optionally followed by the subcommand group and subcommand,
+// separated by spaces. E.g. "ping", "config get" or "config roles add".
+//
+// Parameters:
+//   path - The full path of the command to handle.
+//   callback - The command handler for the given path.
+//
+// Returns the router, allowing calls to be chained.
+//
+// See: [IDiscordCommandFn]
+// See: [DiscordInteractionUnit.CommandPath]
+func (self *CommandRouter) Handle(path string, callback IDiscordCommandFn) *CommandRouter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.routes[normalizeCommandPath(path)] = callback
+	return self
+}
+
+// Remove unregisters the command handler of a command path.
+//
+// Parameters:
+//   path - The full path of the command to remove.
+//
+// Returns true if a handler was registered for the path.
+func (self *CommandRouter) Remove(path string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	path = normalizeCommandPath(path)
+	if _, ok := self.routes[path]; !ok {
+		return false
+	}
+
+	delete(self.routes, path)
+	delete(self.specs, path)
+	delete(self.autocomplete, path)
+	return true
+}
+
+// Command registers a command handler for a command path together with its definition,
+// so it is included in [CommandRouter.ApplicationCommands].
+//
+// Parameters:
+//   path - The full path of the command to handle.
+//   description - The description of the command or subcommand.
+//   args - The tagged options struct, as used with [BuildCommand] and [DiscordInteractionUnit.Bind]. May be nil.
+//   callback - The command handler for the given path.
+//
+// Returns the router, allowing calls to be chained.
+//
+// See: [BuildCommand]
+// See: [BindCommand]
+func (self *CommandRouter) Command(path string, description string, args any, callback IDiscordCommandFn) *CommandRouter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	path = normalizeCommandPath(path)
+	self.routes[path] = callback
+	self.specs[path] = &commandSpec{
+		description: description,
+		args: args,
+	}
+
+	return self
+}
+
+// Describe sets the description of a command or subcommand group that only holds subcommands.
+//
+// Parameters:
+//   path - The path of the command or subcommand group.
+//   description - The description to use.
+//
+// Returns the router, allowing calls to be chained.
+func (self *CommandRouter) Describe(path string, description string) *CommandRouter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	path = normalizeCommandPath(path)
+	if spec, ok := self.specs[path]; ok {
+		spec.description = description
+	} else {
+		self.specs[path] = &commandSpec{description: description}
+	}
+
+	return self
+}
+
+// ApplicationCommands generates the command definitions of every path registered with [CommandRouter.Command],
+// and of every context menu command registered with [CommandRouter.UserCommand] and [CommandRouter.MessageCommand].
+// Paths with two or three segments become subcommands and subcommand groups of their top-level command.
+//
+// Returns the generated definitions sorted by name on success, otherwise an error.
+//
+// See: [BuildCommand]
+// See: [DiscordUnit.Start]
+func (self *CommandRouter) ApplicationCommands() ([]*discordgo.ApplicationCommand, error) {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	roots := make(map[string][]string)
+
+	for path := range self.specs {
+		if _, ok := self.routes[path]; !ok {
+			continue
+		}
+
+		segments := strings.Split(path, " ")
+		if len(segments) > 3 {
+			return nil, fmt.Errorf("failed to build command '%s': paths are limited to a command, group and subcommand", path)
+		}
+
+		roots[segments[0]] = append(roots[segments[0]], path)
+	}
+
+	result := make([]*discordgo.ApplicationCommand, 0, len(roots))
+
+	for name, paths := range roots {
+		command, err := BuildCommand(name, self.description(name), self.args(name))
+		if err != nil {
+			return nil, err
+		}
+
+		self.markAutocomplete(name, command.Options)
+
+		sort.Strings(paths)
+		for _, path := range paths {
+			if path == name {
+				continue
+			}
+
+			if self.args(name) != nil {
+				return nil, fmt.Errorf("failed to build command '%s': a command with options cannot have subcommands", name)
+			}
+
+			err = self.buildSubcommand(command, strings.Split(path, " "))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, command)
+	}
+
+	for commandType, routes := range self.contextRoutes {
+		for name := range routes {
+			result = append(result, &discordgo.ApplicationCommand{
+				Type: commandType,
+				Name: name,
+			})
+		}
+	}
+
+	sort.Slice(result, func (a, b int) bool {
+		if result[a].Name == result[b].Name {
+			return result[a].Type < result[b].Type
+		}
+
+		return result[a].Name < result[b].Name
+	})
+
+	return result, nil
+}
+
+// buildSubcommand adds the subcommand at a path to a command definition, creating its group if needed.
+// The caller must hold the read lock.
+func (self *CommandRouter) buildSubcommand(command *discordgo.ApplicationCommand, segments []string) error {
+	path := strings.Join(segments, " ")
+
+	options, err := buildCommandOptions(self.args(path))
+	if err != nil {
+		return fmt.Errorf("failed to build command '%s': %w", path, err)
+	}
+
+	self.markAutocomplete(path, options)
+
+	subcommand := &discordgo.ApplicationCommandOption{
+		Type: discordgo.ApplicationCommandOptionSubCommand,
+		Name: segments[len(segments) - 1],
+		Description: describe(self.description(path), segments[len(segments) - 1]),
+		Options: options,
+	}
+
+	if len(segments) == 2 {
+		command.Options = append(command.Options, subcommand)
+		return nil
+	}
+
+	groupPath := strings.Join(segments[:2], " ")
+	if self.args(groupPath) != nil {
+		return fmt.Errorf("failed to build command '%s': a subcommand with options cannot have subcommands", groupPath)
+	}
+
+	for _, option := range command.Options {
+		if option.Name == segments[1] {
+			if option.Type != discordgo.ApplicationCommandOptionSubCommandGroup {
+				return fmt.Errorf("failed to build command '%s': '%s' is both a subcommand and a group", path, groupPath)
+			}
+
+			option.Options = append(option.Options, subcommand)
+			return nil
+		}
+	}
+
+	command.Options = append(command.Options, &discordgo.ApplicationCommandOption{
+		Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name: segments[1],
+		Description: describe(self.description(groupPath), segments[1]),
+		Options: []*discordgo.ApplicationCommandOption{subcommand},
+	})
+
+	return nil
+}
+
+// description returns the registered description of a path. The caller must hold the read lock.
+func (self *CommandRouter) description(path string) string {
+	if spec, ok := self.specs[path]; ok {
+		return spec.description
+	}
+
+	return ""
+}
+
+// args returns the registered options struct of a path. The caller must hold the read lock.
+func (self *CommandRouter) args(path string) any {
+	if spec, ok := self.specs[path]; ok {
+		return spec.args
+	}
+
+	return nil
+}
+
+// NoHandler sets the hook that is run when no handler matches the command path.
+// If no hook is set, [CommandRouter.Dispatch] returns an error wrapping [ErrNoHandler].
+//
+// Parameters:
+//   callback - The handler to run for unknown commands, or nil to clear the hook.
+//
+// Returns the router, allowing calls to be chained.
+func (self *CommandRouter) NoHandler(callback IDiscordCommandFn) *CommandRouter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.noHandler = callback
+	return self
+}
+
+// OnValidationError sets the hook that is run when a handler returns a [DiscordOptionError],
+// typically from [DiscordInteractionUnit.Bind].
+// If no hook is set, the router replies to the user with [DiscordOptionError.Message] in an ephemeral message.
+//
+// Parameters:
+//   callback - The handler to run for validation errors, or nil to restore the default.
+//
+// Returns the router, allowing calls to be chained.
+func (self *CommandRouter) OnValidationError(callback func(IDiscordInteractionUnit, *DiscordOptionError) error) *CommandRouter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.validation = callback
+	return self
+}
+
+// Paths returns the sorted paths of all registered command handlers.
+func (self *CommandRouter) Paths() []string {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	result := make([]string, 0, len(self.routes))
+	for path := range self.routes {
+		result = append(result, path)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// Dispatch finds the handler for the interaction's command path and runs it.
+//
+// The most specific path wins: for "config roles add" the handlers for "config roles add",
+// "config roles" and "config" are tried in that order.
+// Interactions that are not slash commands, including context menu commands, are ignored.
+//
+// Parameters:
+//   interaction - The interaction to route.
+//
+// Returns the error of the handler, or an error wrapping [ErrNoHandler] if nothing matched.
+// A [DiscordOptionError] returned by the handler is passed to the validation hook instead.
+//
+// See: [DiscordInteractionUnit.CommandPath]
+// See: [CommandRouter.OnValidationError]
+func (self *CommandRouter) Dispatch(interaction IDiscordInteractionUnit) error {
+	if !isChatCommand(interaction.Native()) {
+		return nil
+	}
+
+	path := interaction.CommandPath()
+
+	self.mutex.RLock()
+	callback := self.lookup(path)
+	noHandler := self.noHandler
+	validation := self.validation
+	self.mutex.RUnlock()
+
+	if callback == nil {
+		callback = noHandler
+	}
+
+	if callback == nil {
+		return fmt.Errorf("%w: '%s'", ErrNoHandler, path)
+	}
+
+	err := callback(interaction)
+
+	var optionErr *DiscordOptionError
+	if !errors.As(err, &optionErr) {
+		return err
+	}
+
+	if validation != nil {
+		return validation(interaction, optionErr)
+	}
+
+	return replyValidationError(interaction, optionErr)
+}
+
+// replyValidationError is the default validation hook, replying with an ephemeral message.
+func replyValidationError(interaction IDiscordInteractionUnit, err *DiscordOptionError) error {
+	return interaction.Respond(DiscordMessageSend{
+		Content: err.Message(),
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// lookup finds the most specific handler for a path. The caller must hold the read lock.
+func (self *CommandRouter) lookup(path string) IDiscordCommandFn {
+	for {
+		if callback, ok := self.routes[path]; ok {
+			return callback
+		}
+
+		index := strings.LastIndexByte(path, ' ')
+		if index < 0 {
+			return nil
+		}
+
+		path = path[:index]
+	}
+}
+
+// normalizeCommandPath collapses any whitespace in a command path into single spaces.
+func normalizeCommandPath(path string) string {
+	return strings.Join(strings.Fields(path), " ")
+}
@@ -0,0 +1,163 @@
+package ktncordgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DiscordMaxMemberSearch is the maximum number of members a single member search returns.
+const DiscordMaxMemberSearch = 1000
+
+// memberReference matches a user mention or a raw user ID.
+var memberReference = regexp.MustCompile(`^(?:<@!?(\d+)>|(\d{15,21}))$`)
+
+// SearchMembers finds members of the discord guild whose username or nickname starts with a query.
+//
+// Parameters:
+//   query - The start of the username or nickname, matched case-insensitively.
+//   limit - The maximum number of members, between 1 and 1000.
+//
+// Returns the matching members on success, a [DiscordArgumentError] if limit is out of range, otherwise an error.
+//
+// See: [DiscordMemberUnit]
+// See: [discordgo.Session.GuildMembersSearch]
+func (self *DiscordGuildUnit) SearchMembers(query string, limit int) ([]IDiscordMemberUnit, error) {
+	if limit < 1 || limit > DiscordMaxMemberSearch {
+		return nil, &DiscordArgumentError{
+			Argument: "limit",
+			Value: limit,
+			Reason: fmt.Sprintf("must be between 1 and %d", DiscordMaxMemberSearch),
+		}
+	}
+
+	members, err := self.discord.session.GuildMembersSearch(self.guild.ID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search guild members: %w", err)
+	}
+
+	result := make([]IDiscordMemberUnit, len(members))
+
+	for i, member := range members {
+		member.GuildID = self.guild.ID
+		result[i] = &DiscordMemberUnit{
+			discord: self.discord,
+			member: member,
+		}
+	}
+
+	return result, nil
+}
+
+// ResolveMember finds the member of the discord guild meant by user input,
+// which may be a mention, a user ID, a username or a display name.
+//
+// Names are matched case-insensitively. An exact username wins over an exact display name,
+// which wins over a name only starting with the input.
+// Discord only searches usernames and nicknames, so global names are matched among those results.
+//
+// Parameters:
+//   input - The text identifying the member.
+//
+// Returns the member on success, an error wrapping [ErrMemberNotFound] if nothing matches,
+// or an [AmbiguousMemberError] if several members match equally well.
+// Other failures, such as missing access or rate limits, are returned wrapped as they are.
+//
+// See: [DiscordGuildUnit.SearchMembers]
+func (self *DiscordGuildUnit) ResolveMember(input string) (IDiscordMemberUnit, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("failed to resolve member: %w", ErrMemberNotFound)
+	}
+
+	if match := memberReference.FindStringSubmatch(input); match != nil {
+		userId := match[1] + match[2]
+
+		member, err := self.GetMember(userId)
+		if err == nil {
+			return member, nil
+		}
+
+		if !isUnknownMember(err) {
+			return nil, fmt.Errorf("failed to resolve member '%s': %w", input, err)
+		}
+
+		if match[1] != "" {
+			return nil, fmt.Errorf("failed to resolve member '%s': %w", input, ErrMemberNotFound)
+		}
+	}
+
+	// Legacy tags like "name#1234" search by the name alone.
+	name := strings.TrimPrefix(input, "@")
+	if index := strings.LastIndexByte(name, '#'); index > 0 {
+		name = name[:index]
+	}
+
+	candidates, err := self.SearchMembers(name, 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve member '%s': %w", input, err)
+	}
+
+	return pickMember(input, name, candidates)
+}
+
+// pickMember picks the best match for a name among the searched members,
+// preferring an exact username over an exact display name over the remaining candidates.
+func pickMember(input string, name string, candidates []IDiscordMemberUnit) (IDiscordMemberUnit, error) {
+	tiers := [][]IDiscordMemberUnit{
+		filterMembers(candidates, func (member IDiscordMemberUnit) bool {
+			return strings.EqualFold(member.User().Username(), name)
+		}),
+		filterMembers(candidates, func (member IDiscordMemberUnit) bool {
+			return strings.EqualFold(member.DisplayName(), name) || strings.EqualFold(member.User().GlobalName(), name)
+		}),
+		candidates,
+	}
+
+	for _, matches := range tiers {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		}
+
+		return nil, &AmbiguousMemberError{
+			Query: input,
+			Matches: matches,
+		}
+	}
+
+	return nil, fmt.Errorf("failed to resolve member '%s': %w", input, ErrMemberNotFound)
+}
+
+// isUnknownMember returns true if a member fetch failed because the member does not exist.
+func isUnknownMember(err error) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) {
+		return false
+	}
+
+	if restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownMember {
+		return true
+	}
+
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
+
+// filterMembers returns the members matching a predicate.
+func filterMembers(members []IDiscordMemberUnit, predicate func(IDiscordMemberUnit) bool) []IDiscordMemberUnit {
+	result := make([]IDiscordMemberUnit, 0)
+
+	for _, member := range members {
+		if predicate(member) {
+			result = append(result, member)
+		}
+	}
+
+	return result
+}
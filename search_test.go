@@ -0,0 +1,166 @@
+package ktncordgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// searchedMember creates a member unit as returned by a member search.
+func searchedMember(username string, globalName string, nick string) IDiscordMemberUnit {
+	return &DiscordMemberUnit{
+		member: &discordgo.Member{
+			User: &discordgo.User{
+				ID: username,
+				Username: username,
+				GlobalName: globalName,
+			},
+			Nick: nick,
+		},
+	}
+}
+
+func TestPickMember(t *testing.T) {
+	candidates := []IDiscordMemberUnit{
+		searchedMember("alex", "", ""),
+		searchedMember("alexandra", "Alex", ""),
+		searchedMember("sam", "", "Sammy"),
+		searchedMember("samantha", "", "Sammy"),
+		searchedMember("robin", "", ""),
+		searchedMember("robert", "", ""),
+	}
+
+	tests := []struct {
+		name string
+		query string
+		candidates []IDiscordMemberUnit
+		expected string
+		ambiguous int
+		notFound bool
+	}{
+		{
+			name: "exact username wins over display name",
+			query: "ALEX",
+			candidates: candidates[:2],
+			expected: "alex",
+		},
+		{
+			name: "exact display name",
+			query: "alex",
+			candidates: candidates[1:2],
+			expected: "alexandra",
+		},
+		{
+			name: "ambiguous nickname",
+			query: "sammy",
+			candidates: candidates[2:4],
+			ambiguous: 2,
+		},
+		{
+			name: "single prefix match",
+			query: "rob",
+			candidates: candidates[4:5],
+			expected: "robin",
+		},
+		{
+			name: "ambiguous prefix",
+			query: "rob",
+			candidates: candidates[4:],
+			ambiguous: 2,
+		},
+		{
+			name: "no candidates",
+			query: "nobody",
+			notFound: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func (t *testing.T) {
+			member, err := pickMember(test.query, test.query, test.candidates)
+
+			var ambiguous *AmbiguousMemberError
+			switch {
+			case test.notFound:
+				if !errors.Is(err, ErrMemberNotFound) {
+					t.Errorf("expected ErrMemberNotFound, got %v", err)
+				}
+			case test.ambiguous > 0:
+				if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != test.ambiguous {
+					t.Errorf("expected %d ambiguous matches, got %v", test.ambiguous, err)
+				}
+			case err != nil:
+				t.Errorf("pickMember returned %v", err)
+			case member.User().Username() != test.expected:
+				t.Errorf("expected %s, got %s", test.expected, member.User().Username())
+			}
+		})
+	}
+}
+
+func TestIsUnknownMember(t *testing.T) {
+	restError := func (status int, code int) error {
+		return fmt.Errorf("failed to fetch guild member: %w", &discordgo.RESTError{
+			Response: &http.Response{StatusCode: status},
+			Message: &discordgo.APIErrorMessage{Code: code},
+		})
+	}
+
+	tests := map[string]struct {
+		err error
+		expected bool
+	}{
+		"unknown member": {restError(http.StatusNotFound, discordgo.ErrCodeUnknownMember), true},
+		"not found": {restError(http.StatusNotFound, discordgo.ErrCodeUnknownUser), true},
+		"missing access": {restError(http.StatusForbidden, discordgo.ErrCodeMissingAccess), false},
+		"rate limited": {restError(http.StatusTooManyRequests, 0), false},
+		"network": {errors.New("connection reset"), false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func (t *testing.T) {
+			if isUnknownMember(test.err) != test.expected {
+				t.Errorf("expected %t for %v", test.expected, test.err)
+			}
+		})
+	}
+}
+
+func TestSearchMembersLimit(t *testing.T) {
+	guild := &DiscordGuildUnit{guild: &discordgo.Guild{ID: "guild"}}
+
+	for _, limit := range []int{0, -1, DiscordMaxMemberSearch + 1} {
+		_, err := guild.SearchMembers("name", limit)
+
+		var argumentErr *DiscordArgumentError
+		if !errors.As(err, &argumentErr) || argumentErr.Argument != "limit" {
+			t.Errorf("expected a DiscordArgumentError for limit %d, got %v", limit, err)
+		}
+	}
+}
+
+func TestMemberReference(t *testing.T) {
+	tests := map[string]string{
+		"<@123456789012345678>": "123456789012345678",
+		"<@!123456789012345678>": "123456789012345678",
+		"123456789012345678": "123456789012345678",
+		"12345": "",
+		"alex": "",
+	}
+
+	for input, expected := range tests {
+		match := memberReference.FindStringSubmatch(input)
+
+		userId := ""
+		if match != nil {
+			userId = match[1] + match[2]
+		}
+
+		if userId != expected {
+			t.Errorf("expected '%s' for '%s', got '%s'", expected, input, userId)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ktncordgo
+
+import "slices"
+
+// Remove detaches the event handler. Calling it more than once has no effect.
+func (self *DiscordSubscription) Remove() {
+	self.deactivate()
+}
+
+// Active returns true until the handler is removed, or a one-shot handler has fired.
+func (self *DiscordSubscription) Active() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return !self.removed
+}
+
+// deactivate removes the handler, returning true only for the call that removed it.
+func (self *DiscordSubscription) deactivate() bool {
+	self.mutex.Lock()
+	if self.removed {
+		self.mutex.Unlock()
+		return false
+	}
+
+	self.removed = true
+	remove := self.remove
+	self.mutex.Unlock()
+
+	if remove != nil {
+		remove()
+	}
+
+	return true
+}
+
+// addHandler registers an event listener, counting it for intent inference.
+// The discordgo handler running all listeners is installed with the first listener.
+// A one-shot listener removes itself before its first run, so it never runs twice.
+func (self *DiscordUnit) addHandler(listener *discordListener, once bool) IDiscordSubscription {
+	subscription := &DiscordSubscription{}
+	if listener == nil {
+		subscription.removed = true
+		return subscription
+	}
+
+	listener.once = once
+	listener.subscription = subscription
+
+	subscription.remove = func () {
+		self.listenerMutex.Lock()
+		self.listeners = slices.DeleteFunc(slices.Clone(self.listeners), func (other *discordListener) bool {
+			return other == listener
+		})
+		self.listenerMutex.Unlock()
+
+		self.recordEvent(listener.eventType, -1)
+	}
+
+	self.recordEvent(listener.eventType, 1)
+
+	self.listenerMutex.Lock()
+	defer self.listenerMutex.Unlock()
+
+	self.listeners = append(self.listeners, listener)
+
+	if !self.listenersInstalled {
+		self.listenersInstalled = true
+		self.session.AddHandler(self.handleEvent)
+	}
+
+	return subscription
+}
@@ -0,0 +1,467 @@
+package ktncordgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/ktnuity/ktnuitygo"
+)
+
+// SyncCommands registers a set of commands with discord, only touching discord when something changed.
+//
+// The registered commands are fetched and compared by type and name with the given ones.
+// If any command was added, changed or removed, the whole set is applied with a bulk overwrite,
+// which also deletes stale commands. Commands registered on the attached [CommandRouter] are included.
+//
+// Parameters:
+//   commands - a slice of [discordgo.ApplicationCommand] references.
+//   options - The sync options. With DryRun set, the diff is computed but not applied.
+//
+// Returns the report of the sync, and an error on failure.
+//
+// See: [DiscordCommandSyncReport]
+// See: [discordgo.Session.ApplicationCommands]
+// See: [discordgo.Session.ApplicationCommandBulkOverwrite]
+func (self *DiscordUnit) SyncCommands(commands []*discordgo.ApplicationCommand, options DiscordCommandSyncOptions) (*DiscordCommandSyncReport, error) {
+	commands, err := self.mergeRouterCommands(commands)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync commands: %w", err)
+	}
+
+	return self.syncCommands("", commands, options)
+}
+
+// SyncGuildCommands registers a set of commands in a single guild, with the same diffing as [DiscordUnit.SyncCommands].
+// Unlike global commands, guild commands are available immediately.
+// Commands registered on the attached [CommandRouter] are not included.
+//
+// Parameters:
+//   guildId - The ID of the guild to register the commands in.
+//   commands - a slice of [discordgo.ApplicationCommand] references.
+//   options - The sync options. With DryRun set, the diff is computed but not applied.
+//
+// Returns the report of the sync, and an error on failure.
+//
+// See: [DiscordUnit.SyncCommands]
+// See: [DiscordUnit.SetGuildCommands]
+func (self *DiscordUnit) SyncGuildCommands(guildId string, commands []*discordgo.ApplicationCommand, options DiscordCommandSyncOptions) (*DiscordCommandSyncReport, error) {
+	if guildId == "" {
+		return nil, fmt.Errorf("failed to sync guild commands: missing guild ID")
+	}
+
+	report, err := self.syncCommands(guildId, commands, options)
+	if err != nil {
+		return report, err
+	}
+
+	if !options.DryRun {
+		self.syncMutex.Lock()
+		if self.syncedGuilds == nil {
+			self.syncedGuilds = make(map[string][]*discordgo.ApplicationCommand)
+		}
+
+		self.syncedGuilds[guildId] = commands
+		self.syncMutex.Unlock()
+	}
+
+	return report, nil
+}
+
+// SetDevGuild makes [DiscordUnit.Start] register the global commands in a single guild instead,
+// so changes are visible immediately while developing. A command set of the same guild set with [DiscordUnit.SetGuildCommands] is merged in.
+//
+// Parameters:
+//   guildId - The ID of the development guild, or an empty string to register globally.
+func (self *DiscordUnit) SetDevGuild(guildId string) {
+	self.syncMutex.Lock()
+	defer self.syncMutex.Unlock()
+
+	self.devGuildId = guildId
+}
+
+// SetGuildCommands sets a command set that [DiscordUnit.Start] registers in a single guild, e.g. for feature-gated servers.
+//
+// Parameters:
+//   guildId - The ID of the guild.
+//   commands - The commands of the guild, or nil to stop managing the guild's commands.
+func (self *DiscordUnit) SetGuildCommands(guildId string, commands []*discordgo.ApplicationCommand) {
+	self.syncMutex.Lock()
+	defer self.syncMutex.Unlock()
+
+	if self.guildCommands == nil {
+		self.guildCommands = make(map[string][]*discordgo.ApplicationCommand)
+	}
+
+	if commands == nil {
+		delete(self.guildCommands, guildId)
+		return
+	}
+
+	self.guildCommands[guildId] = commands
+}
+
+// SetGuildCommandCleanup makes [DiscordUnit.Stop] remove the commands this unit synced to each guild.
+// Other commands of the application in those guilds are left alone.
+//
+// Parameters:
+//   enabled - Whether to remove guild commands on shutdown.
+func (self *DiscordUnit) SetGuildCommandCleanup(enabled bool) {
+	self.syncMutex.Lock()
+	defer self.syncMutex.Unlock()
+
+	self.guildCleanup = enabled
+}
+
+// RemoveGuildCommands removes all commands of the application from a guild, including ones this unit did not register.
+//
+// Parameters:
+//   guildId - The ID of the guild.
+//
+// Returns an error on failure.
+//
+// See: [discordgo.Session.ApplicationCommandBulkOverwrite]
+func (self *DiscordUnit) RemoveGuildCommands(guildId string) error {
+	appId, err := self.applicationId()
+	if err != nil {
+		return fmt.Errorf("failed to remove guild commands: %w", err)
+	}
+
+	_, err = self.session.ApplicationCommandBulkOverwrite(appId, guildId, []*discordgo.ApplicationCommand{})
+	if err != nil {
+		return fmt.Errorf("failed to remove guild commands: %w", err)
+	}
+
+	self.syncMutex.Lock()
+	delete(self.syncedGuilds, guildId)
+	self.syncMutex.Unlock()
+
+	return nil
+}
+
+// cleanupGuildCommands deletes the registered commands of a guild that match the given synced commands by type and name.
+func (self *DiscordUnit) cleanupGuildCommands(guildId string, commands []*discordgo.ApplicationCommand) error {
+	appId, err := self.applicationId()
+	if err != nil {
+		return fmt.Errorf("failed to clean up guild commands: %w", err)
+	}
+
+	registered, err := self.session.ApplicationCommands(appId, guildId)
+	if err != nil {
+		return fmt.Errorf("failed to clean up guild commands: %w", err)
+	}
+
+	synced := make(map[string]bool, len(commands))
+	for _, command := range commands {
+		synced[commandKey(command)] = true
+	}
+
+	var errs []error
+
+	for _, command := range registered {
+		if !synced[commandKey(command)] {
+			continue
+		}
+
+		err = self.session.ApplicationCommandDelete(appId, guildId, command.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete command '%s': %w", command.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	self.syncMutex.Lock()
+	delete(self.syncedGuilds, guildId)
+	self.syncMutex.Unlock()
+
+	return nil
+}
+
+// syncAll syncs the global commands, or the development guild if set, followed by every guild command set.
+// A command set of the development guild is merged into the global commands, as each sync overwrites the whole guild.
+func (self *DiscordUnit) syncAll(commands []*discordgo.ApplicationCommand, options DiscordCommandSyncOptions) ([]*DiscordCommandSyncReport, error) {
+	self.syncMutex.Lock()
+	devGuildId := self.devGuildId
+	guildCommands := maps.Clone(self.guildCommands)
+	self.syncMutex.Unlock()
+
+	reports := make([]*DiscordCommandSyncReport, 0, 1 + len(guildCommands))
+	var errs []error
+
+	var report *DiscordCommandSyncReport
+	var err error
+
+	if devGuildId != "" {
+		commands, err = self.mergeRouterCommands(commands)
+		if err == nil {
+			commands = mergeCommands(commands, guildCommands[devGuildId])
+			delete(guildCommands, devGuildId)
+
+			report, err = self.SyncGuildCommands(devGuildId, commands, options)
+		}
+	} else {
+		report, err = self.SyncCommands(commands, options)
+	}
+
+	if report != nil {
+		reports = append(reports, report)
+	}
+
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for guildId, guildSet := range guildCommands {
+		report, err = self.SyncGuildCommands(guildId, guildSet, options)
+
+		if report != nil {
+			reports = append(reports, report)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("guild '%s': %w", guildId, err))
+		}
+	}
+
+	return reports, errors.Join(errs...)
+}
+
+// mergeCommands appends the commands of a second set that are not part of the first one, compared by type and name.
+func mergeCommands(commands []*discordgo.ApplicationCommand, extra []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	if len(extra) == 0 {
+		return commands
+	}
+
+	seen := make(map[string]bool, len(commands))
+	for _, command := range commands {
+		seen[commandKey(command)] = true
+	}
+
+	result := slices.Clone(commands)
+	for _, command := range extra {
+		if !seen[commandKey(command)] {
+			result = append(result, command)
+		}
+	}
+
+	return result
+}
+
+// syncCommands computes the diff of a command set against the registered commands of a scope, and applies it.
+func (self *DiscordUnit) syncCommands(guildId string, commands []*discordgo.ApplicationCommand, options DiscordCommandSyncOptions) (*DiscordCommandSyncReport, error) {
+	appId, err := self.applicationId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync commands: %w", err)
+	}
+
+	registered, err := self.session.ApplicationCommands(appId, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch registered commands: %w", err)
+	}
+
+	report := diffCommands(commands, registered)
+	report.GuildId = guildId
+
+	if options.CreateOnly {
+		report.Unchanged = append(report.Unchanged, report.Removed...)
+		report.Removed = nil
+	}
+
+	if options.DryRun || !report.HasChanges() {
+		return report, nil
+	}
+
+	if options.CreateOnly {
+		var errs []error
+
+		for _, command := range slices.Concat(report.Added, report.Changed) {
+			_, err = self.session.ApplicationCommandCreate(appId, guildId, command)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to create command '%s': %w", command.Name, err))
+			}
+		}
+
+		report.Applied = len(errs) == 0
+		return report, errors.Join(errs...)
+	}
+
+	_, err = self.session.ApplicationCommandBulkOverwrite(appId, guildId, commands)
+	if err != nil {
+		return report, fmt.Errorf("failed to overwrite commands: %w", err)
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+// applicationId returns the ID of the application, which is the ID of the bot user.
+// The user is fetched if the session is not open yet, so dry runs work without connecting.
+func (self *DiscordUnit) applicationId() (string, error) {
+	if self.session.State != nil && self.session.State.User != nil {
+		return self.session.State.User.ID, nil
+	}
+
+	user, err := self.session.User("@me")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch application user: %w", err)
+	}
+
+	return user.ID, nil
+}
+
+// diffCommands compares local command definitions with the registered ones.
+func diffCommands(commands []*discordgo.ApplicationCommand, registered []*discordgo.ApplicationCommand) *DiscordCommandSyncReport {
+	report := &DiscordCommandSyncReport{}
+	remaining := make(map[string]*discordgo.ApplicationCommand, len(registered))
+
+	for _, command := range registered {
+		remaining[commandKey(command)] = command
+	}
+
+	for _, command := range commands {
+		key := commandKey(command)
+
+		current, ok := remaining[key]
+		if !ok {
+			report.Added = append(report.Added, command)
+			continue
+		}
+
+		delete(remaining, key)
+
+		if commandsEqual(command, current) {
+			report.Unchanged = append(report.Unchanged, current)
+		} else {
+			report.Changed = append(report.Changed, command)
+		}
+	}
+
+	for _, command := range remaining {
+		report.Removed = append(report.Removed, command)
+	}
+
+	sort.Slice(report.Removed, func (a, b int) bool {
+		return report.Removed[a].Name < report.Removed[b].Name
+	})
+
+	return report
+}
+
+// commandKey identifies a command by its type and name, as names are only unique per type.
+func commandKey(command *discordgo.ApplicationCommand) string {
+	commandType := command.Type
+	if commandType == 0 {
+		commandType = discordgo.ChatApplicationCommand
+	}
+
+	return fmt.Sprintf("%d:%s", commandType, command.Name)
+}
+
+// commandsEqual compares a local command definition with a registered one.
+// Fields left unset locally are compared against the defaults discord fills in.
+func commandsEqual(local *discordgo.ApplicationCommand, remote *discordgo.ApplicationCommand) bool {
+	a := normalizeCommand(local)
+	b := normalizeCommand(remote)
+
+	if local.Contexts == nil {
+		b.Contexts = nil
+	}
+
+	if local.IntegrationTypes == nil {
+		b.IntegrationTypes = nil
+	}
+
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	right, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return string(left) == string(right)
+}
+
+// normalizeCommand copies the comparable fields of a command, filling in discord's defaults.
+func normalizeCommand(command *discordgo.ApplicationCommand) *discordgo.ApplicationCommand {
+	result := &discordgo.ApplicationCommand{
+		Type: command.Type,
+		Name: command.Name,
+		NameLocalizations: normalizeLocalizations(command.NameLocalizations),
+		DefaultPermission: command.DefaultPermission,
+		DefaultMemberPermissions: command.DefaultMemberPermissions,
+		NSFW: command.NSFW,
+		DMPermission: command.DMPermission,
+		Contexts: command.Contexts,
+		IntegrationTypes: command.IntegrationTypes,
+		Description: command.Description,
+		DescriptionLocalizations: normalizeLocalizations(command.DescriptionLocalizations),
+		Options: normalizeOptions(command.Options),
+	}
+
+	if result.Type == 0 {
+		result.Type = discordgo.ChatApplicationCommand
+	}
+
+	if result.DefaultPermission == nil {
+		result.DefaultPermission = ktnuitygo.AsRef(true)
+	}
+
+	if result.NSFW == nil {
+		result.NSFW = ktnuitygo.AsRef(false)
+	}
+
+	if result.DMPermission == nil {
+		result.DMPermission = ktnuitygo.AsRef(true)
+	}
+
+	return result
+}
+
+// normalizeOptions copies a slice of command options, turning empty slices and maps into nil.
+func normalizeOptions(options []*discordgo.ApplicationCommandOption) []*discordgo.ApplicationCommandOption {
+	if len(options) == 0 {
+		return nil
+	}
+
+	return convertAll(options, func (option *discordgo.ApplicationCommandOption) *discordgo.ApplicationCommandOption {
+		result := *option
+		result.Options = normalizeOptions(option.Options)
+
+		if len(result.NameLocalizations) == 0 {
+			result.NameLocalizations = nil
+		}
+
+		if len(result.DescriptionLocalizations) == 0 {
+			result.DescriptionLocalizations = nil
+		}
+
+		if len(result.ChannelTypes) == 0 {
+			result.ChannelTypes = nil
+		}
+
+		if len(result.Choices) == 0 {
+			result.Choices = nil
+		}
+
+		return &result
+	})
+}
+
+// normalizeLocalizations turns an empty localization map into nil.
+func normalizeLocalizations(localizations *map[discordgo.Locale]string) *map[discordgo.Locale]string {
+	if localizations == nil || len(*localizations) == 0 {
+		return nil
+	}
+
+	return localizations
+}
@@ -0,0 +1,200 @@
+package ktncordgo
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/ktnuity/ktnuitygo"
+)
+
+// localCommand creates a command definition as written by hand, with only the fields a bot usually sets.
+func localCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name: "config",
+		Description: "Configure the bot",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type: discordgo.ApplicationCommandOptionString,
+				Name: "mode",
+				Description: "The mode",
+				Required: true,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Fast", Value: "fast"},
+					{Name: "Slow", Value: "slow"},
+				},
+			},
+			{
+				Type: discordgo.ApplicationCommandOptionInteger,
+				Name: "level",
+				Description: "The level",
+				MinValue: ktnuitygo.AsRef(1.0),
+				MaxValue: 10,
+			},
+		},
+	}
+}
+
+// registeredCommand creates the same command as discord returns it, with the fields discord fills in.
+func registeredCommand() *discordgo.ApplicationCommand {
+	command := localCommand()
+	command.ID = "123"
+	command.ApplicationID = "456"
+	command.Version = "789"
+	command.Type = discordgo.ChatApplicationCommand
+	command.DefaultPermission = ktnuitygo.AsRef(true)
+	command.DMPermission = ktnuitygo.AsRef(true)
+	command.NSFW = ktnuitygo.AsRef(false)
+	command.Contexts = &[]discordgo.InteractionContextType{discordgo.InteractionContextGuild, discordgo.InteractionContextBotDM}
+	command.IntegrationTypes = &[]discordgo.ApplicationIntegrationType{discordgo.ApplicationIntegrationGuildInstall}
+	command.NameLocalizations = &map[discordgo.Locale]string{}
+	command.Options[0].NameLocalizations = map[discordgo.Locale]string{}
+	command.Options[1].ChannelTypes = []discordgo.ChannelType{}
+
+	return command
+}
+
+func TestCommandsEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		change func(local *discordgo.ApplicationCommand)
+		equal bool
+	}{
+		{
+			name: "server populated fields",
+			change: func (local *discordgo.ApplicationCommand) {},
+			equal: true,
+		},
+		{
+			name: "explicit defaults",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Type = discordgo.ChatApplicationCommand
+				local.NSFW = ktnuitygo.AsRef(false)
+			},
+			equal: true,
+		},
+		{
+			name: "description",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Description = "Changed"
+			},
+		},
+		{
+			name: "option order",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Options[0], local.Options[1] = local.Options[1], local.Options[0]
+			},
+		},
+		{
+			name: "choice order",
+			change: func (local *discordgo.ApplicationCommand) {
+				choices := local.Options[0].Choices
+				choices[0], choices[1] = choices[1], choices[0]
+			},
+		},
+		{
+			name: "choice value",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Options[0].Choices[1].Value = "slower"
+			},
+		},
+		{
+			name: "same min through another pointer",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Options[1].MinValue = ktnuitygo.AsRef(1.0)
+			},
+			equal: true,
+		},
+		{
+			name: "min value",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Options[1].MinValue = ktnuitygo.AsRef(2.0)
+			},
+		},
+		{
+			name: "min removed",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Options[1].MinValue = nil
+			},
+		},
+		{
+			name: "max value",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Options[1].MaxValue = 11
+			},
+		},
+		{
+			name: "explicit contexts",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.Contexts = &[]discordgo.InteractionContextType{discordgo.InteractionContextGuild}
+			},
+		},
+		{
+			name: "dm permission",
+			change: func (local *discordgo.ApplicationCommand) {
+				local.DMPermission = ktnuitygo.AsRef(false)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func (t *testing.T) {
+			local := localCommand()
+			test.change(local)
+
+			if commandsEqual(local, registeredCommand()) != test.equal {
+				t.Errorf("expected equal to be %t", test.equal)
+			}
+		})
+	}
+}
+
+func TestDiffCommands(t *testing.T) {
+	changed := localCommand()
+	changed.Description = "Changed"
+
+	added := &discordgo.ApplicationCommand{Name: "ping", Description: "Ping"}
+	userCommand := &discordgo.ApplicationCommand{Name: "config", Type: discordgo.UserApplicationCommand}
+
+	stale := registeredCommand()
+	stale.Name = "old"
+
+	report := diffCommands(
+		[]*discordgo.ApplicationCommand{changed, added, userCommand},
+		[]*discordgo.ApplicationCommand{registeredCommand(), stale},
+	)
+
+	if len(report.Changed) != 1 || report.Changed[0] != changed {
+		t.Errorf("expected the changed command, got %v", report.Changed)
+	}
+
+	if len(report.Added) != 2 || report.Added[0] != added || report.Added[1] != userCommand {
+		t.Errorf("expected commands to be keyed by type and name, got added %v", report.Added)
+	}
+
+	if len(report.Removed) != 1 || report.Removed[0].Name != "old" {
+		t.Errorf("expected the stale command to be removed, got %v", report.Removed)
+	}
+
+	if !report.HasChanges() {
+		t.Errorf("expected changes")
+	}
+
+	unchanged := diffCommands([]*discordgo.ApplicationCommand{localCommand()}, []*discordgo.ApplicationCommand{registeredCommand()})
+	if unchanged.HasChanges() || len(unchanged.Unchanged) != 1 {
+		t.Errorf("expected no changes, got %s", unchanged)
+	}
+}
+
+func TestMergeCommands(t *testing.T) {
+	commands := []*discordgo.ApplicationCommand{{Name: "a"}, {Name: "b"}}
+	extra := []*discordgo.ApplicationCommand{{Name: "b"}, {Name: "b", Type: discordgo.MessageApplicationCommand}, {Name: "c"}}
+
+	result := mergeCommands(commands, extra)
+	if len(result) != 4 || result[1] != commands[1] || result[2] != extra[1] || result[3] != extra[2] {
+		t.Errorf("unexpected merge result: %v", result)
+	}
+
+	if len(commands) != 2 {
+		t.Errorf("the first set was modified")
+	}
+}
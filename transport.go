@@ -0,0 +1,34 @@
+package ktncordgo
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// baseURLTransport redirects REST requests aimed at discord to another base URL, e.g. a proxy or a mock server.
+type baseURLTransport struct {
+	base *url.URL
+	next http.RoundTripper
+}
+
+// RoundTrip rewrites the request URL if it targets discord, then passes the request on.
+func (self *baseURLTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	raw := request.URL.String()
+	if !strings.HasPrefix(raw, discordgo.EndpointDiscord) {
+		return self.next.RoundTrip(request)
+	}
+
+	target, err := url.Parse(strings.TrimSuffix(self.base.String(), "/") + "/" + strings.TrimPrefix(raw, discordgo.EndpointDiscord))
+	if err != nil {
+		return nil, err
+	}
+
+	request = request.Clone(request.Context())
+	request.URL = target
+	request.Host = target.Host
+
+	return self.next.RoundTrip(request)
+}
@@ -1,21 +1,105 @@
 package ktncordgo
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"log"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
 
 // DiscordUnit holds the main instance of ktncordgo.
 //
 // See: [discordgo.Session]
 type DiscordUnit struct {
 	session *discordgo.Session
+	logger *log.Logger
+	routerMutex sync.Mutex
+	router atomic.Pointer[CommandRouter]
+	routesInstalled bool
+	contextInstalled bool
+
+	syncMutex sync.Mutex
+	devGuildId string
+	guildCommands map[string][]*discordgo.ApplicationCommand
+	guildCleanup bool
+	syncedGuilds map[string][]*discordgo.ApplicationCommand
+	commandSync DiscordCommandSyncMode
+
+	handlerMutex sync.Mutex
+	handlerEvents map[reflect.Type]int
+	listenerMutex sync.RWMutex
+	listeners []*discordListener
+	listenersInstalled bool
+	inferIntents bool
+	strictIntents bool
+	baseIntents discordgo.Intent
+	privilegedIntents discordgo.Intent
+
+	middlewareMutex sync.RWMutex
+	middleware []DiscordMiddleware
+
+	errorSink func(*HandlerError)
+	errorReply string
+	autoDefer time.Duration
+	autoDeferFlags discordgo.MessageFlags
 }
 
-// DiscordInteractionUnit holds any interaction related functionality,
-// typically seen with interaction events, like slash commands.
+// DiscordBaseInteractionUnit holds the functionality shared by every interaction, like responding and follow-ups.
+// It is embedded by the unit of each interaction type.
 //
 // See: [discordgo.InteractionCreate]
-type DiscordInteractionUnit struct {
+type DiscordBaseInteractionUnit struct {
 	discord *DiscordUnit
 	interaction *discordgo.InteractionCreate
+
+	responseMutex sync.Mutex
+	stateMutex sync.Mutex
+	state DiscordResponseState
+	autoDeferred bool
+	deferredUpdate bool
+	deferFlags discordgo.MessageFlags
+	errorReplied atomic.Bool
+}
+
+// DiscordInteractionUnit holds slash command interactions, adding command names and option binding.
+//
+// See: [DiscordBaseInteractionUnit]
+// See: [discordgo.InteractionCreate]
+type DiscordInteractionUnit struct {
+	DiscordBaseInteractionUnit
+}
+
+// DiscordComponentUnit holds message component interactions, like button clicks and select menu choices.
+//
+// See: [DiscordBaseInteractionUnit]
+// See: [discordgo.MessageComponentInteractionData]
+type DiscordComponentUnit struct {
+	DiscordBaseInteractionUnit
+}
+
+// DiscordModalUnit holds modal submit interactions.
+//
+// See: [DiscordBaseInteractionUnit]
+// See: [discordgo.ModalSubmitInteractionData]
+type DiscordModalUnit struct {
+	DiscordBaseInteractionUnit
+}
+
+// DiscordAutocompleteUnit holds autocomplete interactions, sent while a user types a slash command option.
+//
+// See: [DiscordBaseInteractionUnit]
+type DiscordAutocompleteUnit struct {
+	DiscordBaseInteractionUnit
+}
+
+// DiscordContextMenuUnit holds user and message context menu command interactions.
+//
+// See: [DiscordBaseInteractionUnit]
+type DiscordContextMenuUnit struct {
+	DiscordBaseInteractionUnit
 }
 
 // DiscordGuildUnit is the wrapper for the Guild object
@@ -49,3 +133,71 @@ type DiscordUserUnit struct {
 	discord *DiscordUnit
 	user *discordgo.User
 }
+
+// DiscordMemberUnit is the wrapper for the guild Member object.
+//
+// See: [discordgo.Member]
+type DiscordMemberUnit struct {
+	discord *DiscordUnit
+	member *discordgo.Member
+}
+
+// DiscordRoleUnit is the wrapper for the guild Role object.
+//
+// See: [discordgo.Role]
+type DiscordRoleUnit struct {
+	discord *DiscordUnit
+	guildId string
+	role *discordgo.Role
+}
+
+// DiscordEvent holds an event passing through the handler middleware chain.
+//
+// See: [DiscordMiddleware]
+type DiscordEvent struct {
+	discord *DiscordUnit
+	eventType DiscordEventType
+	interaction IDiscordBaseInteractionUnit
+	message IDiscordMessageUnit
+	native any
+}
+
+// DiscordSubscription is the handle of a registered event handler.
+//
+// See: [DiscordUnit.OnEvent]
+type DiscordSubscription struct {
+	mutex sync.Mutex
+	remove func()
+	removed bool
+}
+
+// discordListener is an event handler registered on a [DiscordUnit], run by the single discordgo handler of the unit.
+// Raw listeners receive the discordgo event as is, other listeners the unit of the event.
+type discordListener struct {
+	eventType reflect.Type
+	raw bool
+	match func(native any) bool
+	handle func(event *DiscordEvent) error
+	once bool
+	subscription *DiscordSubscription
+}
+
+// CommandRouter holds a registry of slash command handlers, routed by their full command path.
+//
+// See: [IDiscordCommandFn]
+// See: [DiscordUnit.Router]
+type CommandRouter struct {
+	mutex sync.RWMutex
+	routes map[string]IDiscordCommandFn
+	specs map[string]*commandSpec
+	noHandler IDiscordCommandFn
+	validation func(IDiscordInteractionUnit, *DiscordOptionError) error
+	autocomplete map[string]map[string]IDiscordAutocompleteFn
+	contextRoutes map[discordgo.ApplicationCommandType]map[string]IDiscordContextMenuFn
+}
+
+// commandSpec holds the definition details of a command path registered on a [CommandRouter].
+type commandSpec struct {
+	description string
+	args any
+}